@@ -2,8 +2,12 @@ package errors
 
 import (
 	"fmt"
-	"reflect"
-	"runtime"
+)
+
+var (
+	callersKey       = NewKey[[]uintptr]("errors.Callers")
+	prefixKey        = NewKey[string]("errors.Prefix")
+	publicMessageKey = NewKey[string]("errors.PublicMessage")
 )
 
 // Behavior describes an additional behavior to be applied to err. When the behavior is invoked, err is assumed to be
@@ -20,28 +24,42 @@ func Metadata(key, value interface{}) Behavior {
 // GetMetadata extracts the given key from the error metadata, or returns nil if not found. If err is a compound error,
 // the key is searched starting from the last inner error, and the first match (if any) is returned.
 func GetMetadata(err error, key interface{}) interface{} {
+	value, _ := lookupMetadata(err, key)
+	return value
+}
+
+// lookupMetadata is like GetMetadata, but also returns whether the key was found.
+func lookupMetadata(err error, key interface{}) (interface{}, bool) {
 	if e, ok := err.(*wrappedError); ok {
-		return e.metadata[key]
+		return e.lookup(key)
+	}
+
+	if e, ok := err.(*sentinelError); ok {
+		return e.defaults.lookup(key)
 	}
 
 	if e, ok := err.(wrappedErrors); ok {
 		for i := len(e) - 1; i >= 0; i-- {
-			if v, ok := e[i].metadata[key]; ok {
-				return v
+			if v, ok := e[i].lookup(key); ok {
+				return v, true
 			}
 		}
 	}
 
-	return nil
+	return nil, false
 }
 
-// Callers is a Behavior that stores a stack trace in the error metadata.
-// It is automatically applied on Wrap.
+// Callers is a Behavior that stores a stack trace in the error metadata, up to the depth set by SetCallersDepth. When
+// re-wrapping, the stack trace is preserved, and only the call site of the new layer is recorded (see Layers). Program
+// counters are resolved to frames lazily, when the stack trace is formatted. It is automatically applied on Wrap.
 func Callers() Behavior {
 	return func(doubleWrap bool, err error) {
+		if doubleWrap {
+			layerCallersKey.With(captureCallers(2, layerCallersDepth))(doubleWrap, err)
+		}
+
 		if GetCallers(err) == nil {
-			callers := make([]uintptr, 1024)
-			Metadata(reflect.ValueOf(Callers), callers[:runtime.Callers(2, callers[:])])(doubleWrap, err)
+			callersKey.With(captureCallers(2, GetCallersDepth()+callersSlack))(doubleWrap, err)
 		}
 	}
 }
@@ -49,10 +67,8 @@ func Callers() Behavior {
 // GetCallers extracts a stack trace from the error metadata, if any.
 // It returns nil if no stack trace was set. The Callers behavior is automatically applied on Wrap.
 func GetCallers(err error) []uintptr {
-	if callers, ok := GetMetadata(err, reflect.ValueOf(Callers)).([]uintptr); ok {
-		return callers
-	}
-	return nil
+	callers, _ := callersKey.Get(err)
+	return callers
 }
 
 // GetCallersOrCurrent extracts a stack trace from the error metadata, if any.
@@ -61,15 +77,19 @@ func GetCallersOrCurrent(err error) []uintptr {
 	if callers := GetCallers(err); callers != nil {
 		return callers
 	}
-	callers := make([]uintptr, 1024)
-	return callers[:runtime.Callers(2, callers[:])]
+	return captureCallers(2, GetCallersDepth())
 }
 
-// Skip returns a Behavior that skips the given amount of trailing frames in the stack trace.
+// Skip returns a Behavior that skips the given amount of trailing frames in the stack traces stored in the current
+// layer. When re-wrapping, the original stack trace is preserved and only the call site of the new layer is affected.
 func Skip(skip int) Behavior {
 	return func(doubleWrap bool, err error) {
-		if callers := GetCallers(err); !doubleWrap && callers != nil && len(callers) > skip {
-			Metadata(reflect.ValueOf(Callers), callers[skip:])(doubleWrap, err)
+		wErr := err.(*wrappedError)
+
+		for _, key := range []*Key[[]uintptr]{callersKey, layerCallersKey} {
+			if callers, ok := wErr.metadata[key].([]uintptr); ok && len(callers) > skip {
+				key.With(callers[skip:])(doubleWrap, err)
+			}
 		}
 	}
 }
@@ -78,32 +98,26 @@ func Skip(skip int) Behavior {
 // The prefixFormat and parameters are first passed through fmt.Sprintf().
 func Prefix(prefixFormat string, a ...interface{}) Behavior {
 	return func(doubleWrap bool, err error) {
-		Metadata(reflect.ValueOf(Prefix), fmt.Sprintf(prefixFormat, a...)+": "+GetPrefix(err))(doubleWrap, err)
+		prefixKey.With(fmt.Sprintf(prefixFormat, a...)+": "+GetPrefix(err))(doubleWrap, err)
 	}
 }
 
 // GetPrefix returns the computed error prefix on the error, if any.
 // It returns "" if no prefix was set.
 func GetPrefix(err error) string {
-	if prefix, ok := GetMetadata(err, reflect.ValueOf(Prefix)).(string); ok {
-		return prefix
-	}
-	return ""
+	return prefixKey.GetOrDefault(err, "")
 }
 
 // PublicMessage returns a behavior that stores a public message in the error metadata.
 // It is useful in API servers where detailed errors are logged, while a different message is returned to clients.
 func PublicMessage(message string) Behavior {
-	return Metadata(reflect.ValueOf(PublicMessage), message)
+	return publicMessageKey.With(message)
 }
 
 // GetPublicMessage extracts a public message from the error metadata, if any.
 // It returns "" if no public message was set.
 func GetPublicMessage(err error) string {
-	if message, ok := GetMetadata(err, reflect.ValueOf(PublicMessage)).(string); ok {
-		return message
-	}
-	return ""
+	return publicMessageKey.GetOrDefault(err, "")
 }
 
 // GetPublicMessageOrDefault extracts a public message from the error metadata, if any.
@@ -0,0 +1,88 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/ibrt/errors"
+)
+
+var (
+	benchmarkStackDepths   = []int{1, 10, 100}
+	benchmarkCallersDepths = []int{1, 32, errors.DefaultCallersDepth}
+	benchmarkLayersDepths  = []int{1, 10, 100}
+	benchmarkErr           error
+	benchmarkValue         interface{}
+)
+
+func runBenchmark(b *testing.B, fn func(b *testing.B)) {
+	for _, callersDepth := range benchmarkCallersDepths {
+		for _, stackDepth := range benchmarkStackDepths {
+			b.Run(fmt.Sprintf("callers=%v/stack=%v", callersDepth, stackDepth), func(b *testing.B) {
+				errors.SetCallersDepth(callersDepth)
+				defer errors.SetCallersDepth(errors.DefaultCallersDepth)
+
+				_ = recurse(stackDepth, func() error {
+					b.ReportAllocs()
+					b.ResetTimer()
+					fn(b)
+					return nil
+				})
+			})
+		}
+	}
+}
+
+func BenchmarkWrap(b *testing.B) {
+	runBenchmark(b, func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchmarkErr = errors.Wrap(io.EOF)
+		}
+	})
+}
+
+func BenchmarkWrap_Rewrap(b *testing.B) {
+	runBenchmark(b, func(b *testing.B) {
+		err := errors.Wrap(io.EOF)
+		b.ResetTimer()
+
+		for i := 0; i < b.N; i++ {
+			benchmarkErr = errors.Wrap(err, errors.Prefix("prefix"))
+		}
+	})
+}
+
+func BenchmarkErrorf(b *testing.B) {
+	runBenchmark(b, func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchmarkErr = errors.Errorf("test error %v", i, errors.HTTPStatusNotFound)
+		}
+	})
+}
+
+func BenchmarkAppend(b *testing.B) {
+	runBenchmark(b, func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			benchmarkErr = errors.Append(io.EOF, io.ErrUnexpectedEOF)
+		}
+	})
+}
+
+func BenchmarkGetMetadata(b *testing.B) {
+	for _, layersDepth := range benchmarkLayersDepths {
+		b.Run(fmt.Sprintf("layers=%v", layersDepth), func(b *testing.B) {
+			runBenchmark(b, func(b *testing.B) {
+				err := errors.Errorf("test error", errors.Metadata("key", "value"))
+				for i := 1; i < layersDepth; i++ {
+					err = errors.Wrap(err, errors.Prefix("prefix %v", i))
+				}
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					benchmarkValue = errors.GetMetadata(err, "key")
+				}
+			})
+		})
+	}
+}
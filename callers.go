@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultCallersDepth is the default maximum depth of the stack traces captured by Callers.
+const DefaultCallersDepth = 1024
+
+// callersSlack is the number of additional frames captured by Callers, so that Skip can be applied without reducing
+// the depth of the resulting stack trace.
+const callersSlack = 16
+
+var (
+	callersDepth atomic.Int64
+	callersPool  = sync.Pool{
+		New: func() interface{} {
+			buf := make([]uintptr, 0)
+			return &buf
+		},
+	}
+)
+
+func init() {
+	callersDepth.Store(DefaultCallersDepth)
+}
+
+// SetCallersDepth sets the maximum depth of the stack traces captured by Callers. A depth of 1 only captures the
+// immediate caller, which minimizes the cost of wrapping errors on hot paths.
+func SetCallersDepth(depth int) {
+	if depth < 1 {
+		panic("invalid depth")
+	}
+	callersDepth.Store(int64(depth))
+}
+
+// GetCallersDepth returns the maximum depth of the stack traces captured by Callers.
+func GetCallersDepth() int {
+	return int(callersDepth.Load())
+}
+
+// captureCallers captures up to depth program counters of the current stack, skipping the given amount of frames, as
+// in runtime.Callers. A pooled buffer is used to capture the stack, so that only the returned slice is allocated.
+func captureCallers(skip, depth int) []uintptr {
+	buf := callersPool.Get().(*[]uintptr)
+	if len(*buf) < depth {
+		*buf = make([]uintptr, depth)
+	}
+
+	n := runtime.Callers(skip+1, (*buf)[:depth])
+	callers := make([]uintptr, n)
+	copy(callers, *buf)
+
+	callersPool.Put(buf)
+	return callers
+}
+
+// finalizeCallers trims the stack traces stored in the current layer of e to their configured depth, and applies the
+// capture time frame filters. It is invoked by Wrap once all behaviors (including Skip) are applied.
+func finalizeCallers(e *wrappedError) {
+	if callers, ok := e.metadata[callersKey].([]uintptr); ok {
+		if depth := GetCallersDepth(); len(callers) > depth {
+			e.metadata[callersKey] = callers[:depth:depth]
+		}
+	}
+
+	if callers, ok := e.metadata[layerCallersKey].([]uintptr); ok && len(callers) > 1 {
+		e.metadata[layerCallersKey] = callers[:1:1]
+	}
+
+	applyCaptureFrameFilters(e)
+}
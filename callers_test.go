@@ -0,0 +1,36 @@
+package errors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCallersDepth(t *testing.T) {
+	require.Equal(t, errors.DefaultCallersDepth, errors.GetCallersDepth())
+	defer errors.SetCallersDepth(errors.DefaultCallersDepth)
+
+	errors.SetCallersDepth(1)
+	require.Equal(t, 1, errors.GetCallersDepth())
+
+	err := recurse(5, func() error { return errors.Errorf("test error") })
+	require.Len(t, errors.GetCallers(err), 1)
+	require.True(t, strings.HasPrefix(errors.FormatCallers(errors.GetCallers(err))[0], "errors_test.TestSetCallersDepth.func1"))
+
+	err = errors.Wrap(err, errors.Prefix("prefix"))
+	require.Len(t, errors.GetCallers(err), 1)
+	require.True(t, strings.HasPrefix(errors.Layers(err)[1].Caller(), "errors_test.TestSetCallersDepth"))
+
+	errors.SetCallersDepth(3)
+	err = recurse(5, func() error { return errors.Errorf("test error") })
+	require.Equal(t, []string{
+		"errors_test.TestSetCallersDepth.func2",
+		"errors_test.recurse",
+		"errors_test.recurse",
+	}, frameFunctions(errors.Frames(err)))
+	require.Len(t, errors.GetCallersOrCurrent(nil), 3)
+
+	require.PanicsWithValue(t, "invalid depth", func() { errors.SetCallersDepth(0) })
+}
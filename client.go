@@ -0,0 +1,171 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DefaultResponseHeaders lists the response headers copied to the error metadata by WrapResponse and Transport.
+var DefaultResponseHeaders = []string{"Content-Type", "Retry-After", "Www-Authenticate", "X-Request-Id"}
+
+var responseInfoKey = NewKey[*ResponseInfo]("errors.ResponseInfo")
+
+// maxResponseBodySize is the maximum amount of bytes read from a response body when decoding an error.
+const maxResponseBodySize = 64 * 1024
+
+// ResponseInfo describes the HTTP request/response pair from which an error was decoded.
+type ResponseInfo struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+}
+
+// GetResponseInfo extracts the ResponseInfo stored by WrapResponse, if any.
+// It returns nil if the error was not decoded from a HTTP response.
+func GetResponseInfo(err error) *ResponseInfo {
+	info, _ := responseInfoKey.Get(err)
+	return info
+}
+
+// IsErrorResponse returns true if the given response has a 4xx or 5xx status code. Informational (1xx) and redirection
+// (3xx) responses, e.g. 101 Switching Protocols, 302 Found or 304 Not Modified, are not errors.
+func IsErrorResponse(resp *http.Response) bool {
+	return resp.StatusCode >= 400
+}
+
+// WrapResponse converts the given response to a wrapped error, applying the given behaviors plus HTTPStatus with the
+// response status code. The public message is decoded from a Problem Details or JSON body ("detail", "message" or
+// "error" members), if any. The request method, URL and the headers listed in DefaultResponseHeaders are stored as
+// ResponseInfo. The response body is buffered and replaced, so that it can still be consumed by the caller.
+func WrapResponse(resp *http.Response, behaviors ...Behavior) error {
+	return wrapResponse(resp, DefaultResponseHeaders, append(behaviors, Skip(1))...)
+}
+
+// MaybeWrapResponse is like WrapResponse, but returns nil unless the response has a 4xx or 5xx status code (see
+// IsErrorResponse).
+func MaybeWrapResponse(resp *http.Response, behaviors ...Behavior) error {
+	if !IsErrorResponse(resp) {
+		return nil
+	}
+	return wrapResponse(resp, DefaultResponseHeaders, append(behaviors, Skip(1))...)
+}
+
+// Transport is a http.RoundTripper that converts responses with a 4xx or 5xx status code (see IsErrorResponse) to
+// wrapped errors, as in WrapResponse, closing their body. Other responses, including redirects, are returned as is.
+// Note that http.Client wraps errors returned by a RoundTripper in a *url.Error, whose Err field holds the wrapped
+// error.
+type Transport struct {
+	// Base is the underlying http.RoundTripper. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+	// Headers lists the response headers copied to the error metadata. If nil, DefaultResponseHeaders is used.
+	Headers []string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	headers := t.Headers
+	if headers == nil {
+		headers = DefaultResponseHeaders
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsErrorResponse(resp) {
+		defer IgnoreClose(resp.Body)
+		return nil, wrapResponse(resp, headers, Skip(1))
+	}
+
+	return resp, nil
+}
+
+// wrapResponse implements WrapResponse.
+func wrapResponse(resp *http.Response, headers []string, behaviors ...Behavior) error {
+	if resp == nil {
+		panic("nil response")
+	}
+
+	info := &ResponseInfo{
+		StatusCode: resp.StatusCode,
+		Header:     make(http.Header),
+	}
+
+	if resp.Request != nil {
+		info.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			info.URL = resp.Request.URL.Redacted()
+		}
+	}
+
+	for _, header := range headers {
+		if values := resp.Header.Values(header); len(values) > 0 {
+			info.Header[http.CanonicalHeaderKey(header)] = values
+		}
+	}
+
+	behaviors = append([]Behavior{
+		HTTPStatus(resp.StatusCode),
+		responseInfoKey.With(info),
+	}, behaviors...)
+
+	if message := decodeResponseMessage(resp); message != "" {
+		behaviors = append([]Behavior{PublicMessage(message)}, behaviors...)
+	}
+
+	behaviors = append(behaviors, Skip(1))
+	return Errorf("%v %v: unexpected status: %v", info.Method, info.URL, resp.Status, Behaviors(behaviors...))
+}
+
+// decodeResponseMessage extracts a message from a Problem Details or JSON response body, if any. The body is buffered
+// and replaced with a reader that returns the same data.
+func decodeResponseMessage(resp *http.Response) string {
+	if resp.Body == nil {
+		return ""
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(buf), resp.Body), resp.Body}
+
+	if err != nil {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return ""
+	}
+
+	body := struct {
+		Detail  string `json:"detail"`
+		Title   string `json:"title"`
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}{}
+
+	if err := json.Unmarshal(buf, &body); err != nil {
+		return ""
+	}
+
+	for _, message := range []string{body.Detail, body.Message, body.Error, body.Title} {
+		if message != "" {
+			return message
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,127 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResponse(status int, contentType, body string) *http.Response {
+	resp := &http.Response{
+		Status:     fmt.Sprintf("%v %v", status, http.StatusText(status)),
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    httptest.NewRequest(http.MethodGet, "http://example.com/users/1", nil),
+	}
+	resp.Header.Set("Content-Type", contentType)
+	resp.Header.Set("X-Request-Id", "request-id")
+	resp.Header.Set("X-Other", "other")
+	return resp
+}
+
+func ExampleWrapResponse() {
+	resp := newTestResponse(http.StatusNotFound, errors.ProblemContentType, `{"detail":"user not found"}`)
+	err := errors.WrapResponse(resp)
+
+	fmt.Println(err.Error())
+	fmt.Println(errors.GetHTTPStatus(err))
+	fmt.Println(errors.GetPublicMessage(err))
+
+	// Output:
+	// GET http://example.com/users/1: unexpected status: 404 Not Found
+	// 404
+	// user not found
+}
+
+func TestWrapResponse(t *testing.T) {
+	resp := newTestResponse(http.StatusBadRequest, "application/json; charset=utf-8", `{"message":"bad input"}`)
+	err := errors.WrapResponse(resp, errors.Prefix("prefix"))
+	require.EqualError(t, err, "prefix: GET http://example.com/users/1: unexpected status: 400 Bad Request")
+	require.Equal(t, http.StatusBadRequest, errors.GetHTTPStatus(err))
+	require.Equal(t, "bad input", errors.GetPublicMessage(err))
+	require.True(t, strings.HasPrefix(errors.FormatCallers(errors.GetCallers(err))[0], "errors_test.TestWrapResponse"))
+
+	info := errors.GetResponseInfo(err)
+	require.Equal(t, http.MethodGet, info.Method)
+	require.Equal(t, "http://example.com/users/1", info.URL)
+	require.Equal(t, http.StatusBadRequest, info.StatusCode)
+	require.Equal(t, "request-id", info.Header.Get("X-Request-Id"))
+	require.Empty(t, info.Header.Get("X-Other"))
+
+	body, readErr := io.ReadAll(resp.Body)
+	require.NoError(t, readErr)
+	require.Equal(t, `{"message":"bad input"}`, string(body))
+
+	err = errors.WrapResponse(newTestResponse(http.StatusBadGateway, "text/plain", `{"message":"ignored"}`))
+	require.Equal(t, http.StatusBadGateway, errors.GetHTTPStatus(err))
+	require.Equal(t, "", errors.GetPublicMessage(err))
+
+	err = errors.WrapResponse(newTestResponse(http.StatusBadGateway, "application/json", `not json`))
+	require.Equal(t, "", errors.GetPublicMessage(err))
+
+	require.Nil(t, errors.GetResponseInfo(errors.Errorf("test error")))
+	require.PanicsWithValue(t, "nil response", func() { _ = errors.WrapResponse(nil) })
+}
+
+func TestMaybeWrapResponse(t *testing.T) {
+	require.Nil(t, errors.MaybeWrapResponse(newTestResponse(http.StatusOK, "application/json", `{}`)))
+	require.Nil(t, errors.MaybeWrapResponse(newTestResponse(http.StatusNotModified, "application/json", ``)))
+	err := errors.MaybeWrapResponse(newTestResponse(http.StatusConflict, "application/json", `{"error":"conflict"}`))
+	require.Equal(t, http.StatusConflict, errors.GetHTTPStatus(err))
+	require.Equal(t, "conflict", errors.GetPublicMessage(err))
+	require.True(t, strings.HasPrefix(errors.FormatCallers(errors.GetCallers(err))[0], "errors_test.TestMaybeWrapResponse"))
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte("ok"))
+			return
+		case "/old":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+			return
+		case "/cached":
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		errors.WriteProblem(w, r, errors.Errorf("not found", errors.HTTPStatusNotFound, errors.PublicMessage("no such user")))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &errors.Transport{}}
+
+	resp, err := client.Get(srv.URL + "/ok")
+	require.NoError(t, err)
+	defer errors.IgnoreClose(resp.Body)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = client.Get(srv.URL + "/old")
+	require.NoError(t, err)
+	defer errors.IgnoreClose(resp.Body)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "/ok", resp.Request.URL.Path)
+
+	resp, err = client.Get(srv.URL + "/cached")
+	require.NoError(t, err)
+	defer errors.IgnoreClose(resp.Body)
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	_, err = client.Get(srv.URL + "/users/1")
+	require.Error(t, err)
+
+	var urlErr *url.Error
+	require.True(t, stderrors.As(err, &urlErr))
+	require.Equal(t, http.StatusNotFound, errors.GetHTTPStatus(urlErr.Err))
+	require.Equal(t, "no such user", errors.GetPublicMessage(urlErr.Err))
+	require.Equal(t, errors.ProblemContentType, errors.GetResponseInfo(urlErr.Err).Header.Get("Content-Type"))
+}
@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Code is a canonical status code, as defined by gRPC. It is implemented locally, so that no dependency on gRPC is
+// required. See: https://github.com/grpc/grpc/blob/master/doc/statuscodes.md
+type Code uint32
+
+// Canonical status codes, as defined by gRPC.
+const (
+	CodeOK                 Code = 0
+	CodeCanceled           Code = 1
+	CodeUnknown            Code = 2
+	CodeInvalidArgument    Code = 3
+	CodeDeadlineExceeded   Code = 4
+	CodeNotFound           Code = 5
+	CodeAlreadyExists      Code = 6
+	CodePermissionDenied   Code = 7
+	CodeResourceExhausted  Code = 8
+	CodeFailedPrecondition Code = 9
+	CodeAborted            Code = 10
+	CodeOutOfRange         Code = 11
+	CodeUnimplemented      Code = 12
+	CodeInternal           Code = 13
+	CodeUnavailable        Code = 14
+	CodeDataLoss           Code = 15
+	CodeUnauthenticated    Code = 16
+)
+
+var codeKey = NewKey[Code]("errors.Code")
+
+var codeNames = map[Code]string{
+	CodeOK:                 "OK",
+	CodeCanceled:           "Canceled",
+	CodeUnknown:            "Unknown",
+	CodeInvalidArgument:    "InvalidArgument",
+	CodeDeadlineExceeded:   "DeadlineExceeded",
+	CodeNotFound:           "NotFound",
+	CodeAlreadyExists:      "AlreadyExists",
+	CodePermissionDenied:   "PermissionDenied",
+	CodeResourceExhausted:  "ResourceExhausted",
+	CodeFailedPrecondition: "FailedPrecondition",
+	CodeAborted:            "Aborted",
+	CodeOutOfRange:         "OutOfRange",
+	CodeUnimplemented:      "Unimplemented",
+	CodeInternal:           "Internal",
+	CodeUnavailable:        "Unavailable",
+	CodeDataLoss:           "DataLoss",
+	CodeUnauthenticated:    "Unauthenticated",
+}
+
+var codeToHTTPStatus = map[Code]int{
+	CodeOK:                 http.StatusOK,
+	CodeCanceled:           499, // client closed request
+	CodeUnknown:            http.StatusInternalServerError,
+	CodeInvalidArgument:    http.StatusBadRequest,
+	CodeDeadlineExceeded:   http.StatusGatewayTimeout,
+	CodeNotFound:           http.StatusNotFound,
+	CodeAlreadyExists:      http.StatusConflict,
+	CodePermissionDenied:   http.StatusForbidden,
+	CodeResourceExhausted:  http.StatusTooManyRequests,
+	CodeFailedPrecondition: http.StatusBadRequest,
+	CodeAborted:            http.StatusConflict,
+	CodeOutOfRange:         http.StatusBadRequest,
+	CodeUnimplemented:      http.StatusNotImplemented,
+	CodeInternal:           http.StatusInternalServerError,
+	CodeUnavailable:        http.StatusServiceUnavailable,
+	CodeDataLoss:           http.StatusInternalServerError,
+	CodeUnauthenticated:    http.StatusUnauthorized,
+}
+
+var httpStatusToCode = map[int]Code{
+	http.StatusBadRequest:                   CodeInvalidArgument,
+	http.StatusUnauthorized:                 CodeUnauthenticated,
+	http.StatusForbidden:                    CodePermissionDenied,
+	http.StatusNotFound:                     CodeNotFound,
+	http.StatusMethodNotAllowed:             CodeUnimplemented,
+	http.StatusRequestTimeout:               CodeDeadlineExceeded,
+	http.StatusConflict:                     CodeAlreadyExists,
+	http.StatusPreconditionFailed:           CodeFailedPrecondition,
+	http.StatusRequestedRangeNotSatisfiable: CodeOutOfRange,
+	http.StatusTooManyRequests:              CodeResourceExhausted,
+	499:                                     CodeCanceled, // client closed request
+	http.StatusInternalServerError:          CodeInternal,
+	http.StatusNotImplemented:               CodeUnimplemented,
+	http.StatusBadGateway:                   CodeUnavailable,
+	http.StatusServiceUnavailable:           CodeUnavailable,
+	http.StatusGatewayTimeout:               CodeDeadlineExceeded,
+}
+
+// String implements fmt.Stringer.
+func (c Code) String() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+	return "Code(" + strconv.FormatUint(uint64(c), 10) + ")"
+}
+
+// HTTPStatus returns the HTTP status corresponding to the code. Unknown codes map to 500.
+func (c Code) HTTPStatus() int {
+	if status, ok := codeToHTTPStatus[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeFromHTTPStatus returns the code corresponding to the given HTTP status. Unmapped 2xx statuses map to CodeOK,
+// unmapped 4xx statuses to CodeFailedPrecondition, unmapped 5xx statuses to CodeInternal, and others to CodeUnknown.
+func CodeFromHTTPStatus(status int) Code {
+	if code, ok := httpStatusToCode[status]; ok {
+		return code
+	}
+
+	switch {
+	case status >= 200 && status <= 299:
+		return CodeOK
+	case status >= 400 && status <= 499:
+		return CodeFailedPrecondition
+	case status >= 500 && status <= 599:
+		return CodeInternal
+	default:
+		return CodeUnknown
+	}
+}
+
+// WithCode returns a behavior that stores a canonical status code in the error metadata.
+func WithCode(code Code) Behavior {
+	return codeKey.With(code)
+}
+
+// GetCode extracts a canonical status code from the error metadata, if any. If no code was set, but a HTTP status is
+// available (see GetHTTPStatus), the corresponding code is returned. It returns CodeUnknown if neither is.
+func GetCode(err error) Code {
+	return GetCodeOrDefault(err, CodeUnknown)
+}
+
+// GetCodeOrDefault extracts a canonical status code from the error metadata, if any. If no code was set, but a HTTP
+// status is available (see GetHTTPStatus), the corresponding code is returned. It returns the given default code if
+// neither is.
+func GetCodeOrDefault(err error, defaultCode Code) Code {
+	if code, ok := codeKey.Get(err); ok {
+		return code
+	}
+	if status := GetHTTPStatus(err); status != 0 {
+		return CodeFromHTTPStatus(status)
+	}
+	return defaultCode
+}
@@ -0,0 +1,86 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleWithCode() {
+	err := errors.Errorf("test error", errors.WithCode(errors.CodeNotFound))
+	fmt.Println(errors.GetCode(err))
+	fmt.Println(errors.GetHTTPStatus(err))
+
+	err = errors.Errorf("test error", errors.HTTPStatusTooManyRequests)
+	fmt.Println(errors.GetCode(err))
+
+	// Output:
+	// NotFound
+	// 404
+	// ResourceExhausted
+}
+
+func TestCode(t *testing.T) {
+	err := errors.Errorf("test error")
+	require.Equal(t, errors.CodeUnknown, errors.GetCode(err))
+	require.Equal(t, errors.CodeInternal, errors.GetCodeOrDefault(err, errors.CodeInternal))
+	require.Equal(t, 0, errors.GetHTTPStatus(err))
+
+	err = errors.Errorf("test error", errors.WithCode(errors.CodeUnavailable))
+	require.Equal(t, errors.CodeUnavailable, errors.GetCode(err))
+	require.Equal(t, errors.CodeUnavailable, errors.GetCodeOrDefault(err, errors.CodeInternal))
+	require.Equal(t, http.StatusServiceUnavailable, errors.GetHTTPStatus(err))
+
+	err = errors.Wrap(err, errors.HTTPStatusBadGateway)
+	require.Equal(t, errors.CodeUnavailable, errors.GetCode(err))
+	require.Equal(t, http.StatusBadGateway, errors.GetHTTPStatus(err))
+
+	err = errors.Errorf("test error", errors.HTTPStatusForbidden)
+	require.Equal(t, errors.CodePermissionDenied, errors.GetCode(err))
+
+	err = errors.Errorf("test error", errors.WithCode(errors.CodeOK))
+	require.Equal(t, errors.CodeOK, errors.GetCode(err))
+	require.Equal(t, 0, errors.GetHTTPStatus(err))
+	require.Equal(t, http.StatusInternalServerError, errors.GetHTTPStatusOrDefault(err, http.StatusInternalServerError))
+
+	w := httptest.NewRecorder()
+	errors.WriteProblem(w, nil, err)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestCode_String(t *testing.T) {
+	require.Equal(t, "OK", errors.CodeOK.String())
+	require.Equal(t, "Unauthenticated", errors.CodeUnauthenticated.String())
+	require.Equal(t, "Code(17)", errors.Code(17).String())
+}
+
+func TestCode_HTTPStatus(t *testing.T) {
+	roundTrip := []errors.Code{
+		errors.CodeOK, errors.CodeCanceled, errors.CodeInvalidArgument, errors.CodeDeadlineExceeded,
+		errors.CodeNotFound, errors.CodeAlreadyExists, errors.CodePermissionDenied, errors.CodeResourceExhausted,
+		errors.CodeUnimplemented, errors.CodeInternal, errors.CodeUnavailable, errors.CodeUnauthenticated,
+	}
+
+	for _, code := range roundTrip {
+		t.Run(code.String(), func(t *testing.T) {
+			require.Equal(t, code, errors.CodeFromHTTPStatus(code.HTTPStatus()))
+		})
+	}
+
+	require.Equal(t, 499, errors.CodeCanceled.HTTPStatus())
+	require.Equal(t, http.StatusBadRequest, errors.CodeFailedPrecondition.HTTPStatus())
+	require.Equal(t, http.StatusInternalServerError, errors.Code(17).HTTPStatus())
+}
+
+func TestCodeFromHTTPStatus(t *testing.T) {
+	require.Equal(t, errors.CodeOK, errors.CodeFromHTTPStatus(http.StatusCreated))
+	require.Equal(t, errors.CodeNotFound, errors.CodeFromHTTPStatus(http.StatusNotFound))
+	require.Equal(t, errors.CodeFailedPrecondition, errors.CodeFromHTTPStatus(http.StatusTeapot))
+	require.Equal(t, errors.CodeUnavailable, errors.CodeFromHTTPStatus(http.StatusBadGateway))
+	require.Equal(t, errors.CodeInternal, errors.CodeFromHTTPStatus(http.StatusLoopDetected))
+	require.Equal(t, errors.CodeUnknown, errors.CodeFromHTTPStatus(http.StatusMovedPermanently))
+}
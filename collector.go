@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CollectorOption describes an option for NewCollector.
+type CollectorOption func(*Collector)
+
+// CollectorMaxErrors returns a CollectorOption that limits the number of errors kept by the Collector. Errors added
+// beyond the limit are only counted, and summarized by an additional "and N more errors" inner error.
+func CollectorMaxErrors(maxErrors int) CollectorOption {
+	return func(c *Collector) {
+		c.maxErrors = maxErrors
+	}
+}
+
+// CollectorDedupe returns a CollectorOption that discards errors that equal (see Equals) an error already collected.
+func CollectorDedupe() CollectorOption {
+	return func(c *Collector) {
+		c.dedupe = true
+	}
+}
+
+// Collector gathers errors into a compound error, and is safe for concurrent use. The zero value is ready to use, with
+// no limit and no deduplication.
+type Collector struct {
+	m         sync.Mutex
+	errs      wrappedErrors
+	dropped   int
+	maxErrors int
+	dedupe    bool
+}
+
+// NewCollector creates a new Collector with the given options.
+func NewCollector(options ...CollectorOption) *Collector {
+	c := &Collector{}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Add adds err to the collector. If err is a compound error, each of its inner errors is added. Unwrapped errors are
+// wrapped, with a stack trace starting at the caller. Nil errors are ignored.
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	var wErrs wrappedErrors
+
+	switch err := err.(type) {
+	case *wrappedError:
+		wErrs = wrappedErrors{err}
+	case wrappedErrors:
+		wErrs = err
+	default:
+		wErrs = wrappedErrors{Wrap(err, Skip(1)).(*wrappedError)}
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	for _, wErr := range wErrs {
+		c.add(wErr)
+	}
+}
+
+// Addf is like Add, but creates the error as in Errorf.
+func (c *Collector) Addf(format string, behaviorOrArg ...interface{}) {
+	c.Add(Errorf(format, append(behaviorOrArg, Skip(1))...))
+}
+
+// Len returns the number of errors added so far, including the ones beyond the limit set by CollectorMaxErrors, but
+// excluding the ones discarded as duplicates.
+func (c *Collector) Len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return len(c.errs) + c.dropped
+}
+
+// Err returns the collected errors, or nil if no error was added. If a single error was added it is returned as is,
+// otherwise a compound error is returned (see Append).
+func (c *Collector) Err() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if len(c.errs) == 0 {
+		return nil
+	}
+
+	if len(c.errs) == 1 && c.dropped == 0 {
+		return c.errs[0]
+	}
+
+	errs := append(make(wrappedErrors, 0, len(c.errs)+1), c.errs...)
+	if c.dropped > 0 {
+		errs = append(errs, Wrap(fmt.Errorf("and %v more errors", c.dropped), Skip(1)).(*wrappedError))
+	}
+
+	return errs
+}
+
+// add adds a single error, applying deduplication and limit. It must be called while holding the lock.
+func (c *Collector) add(wErr *wrappedError) {
+	if c.dedupe {
+		for _, existing := range c.errs {
+			if Equals(existing, wErr) {
+				return
+			}
+		}
+	}
+
+	if c.maxErrors > 0 && len(c.errs) >= c.maxErrors {
+		c.dropped++
+		return
+	}
+
+	c.errs = append(c.errs, wErr)
+}
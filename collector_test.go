@@ -0,0 +1,99 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleCollector() {
+	c := errors.NewCollector(errors.CollectorMaxErrors(2))
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Addf("test error")
+		}()
+	}
+
+	wg.Wait()
+	fmt.Println(c.Len())
+	fmt.Println(c.Err().Error())
+
+	// Output:
+	// 5
+	// multiple errors: test error · test error · and 3 more errors
+}
+
+func TestCollector(t *testing.T) {
+	c := &errors.Collector{}
+	require.Equal(t, 0, c.Len())
+	require.NoError(t, c.Err())
+
+	c.Add(nil)
+	require.NoError(t, c.Err())
+
+	c.Add(fmt.Errorf("first error"))
+	require.Equal(t, 1, c.Len())
+	require.EqualError(t, c.Err(), "first error")
+	require.Len(t, errors.Split(c.Err()), 1)
+	require.True(t, strings.HasPrefix(errors.GetFormattedCallers(c.Err())[0], "errors_test.TestCollector"))
+
+	err := c.Err()
+	c.Addf("second error", errors.Metadata("k", "v"))
+	c.Add(errors.Append(errors.Errorf("third error"), errors.Errorf("fourth error")))
+	require.Equal(t, 4, c.Len())
+	require.EqualError(t, c.Err(), "multiple errors: first error · second error · third error · fourth error")
+	require.Equal(t, "v", errors.GetMetadata(c.Err(), "k"))
+	require.True(t, strings.HasPrefix(errors.GetFormattedCallers(errors.Split(c.Err())[1])[0], "errors_test.TestCollector"))
+	require.EqualError(t, err, "first error")
+}
+
+func TestCollector_Options(t *testing.T) {
+	errTest := fmt.Errorf("test error")
+
+	c := errors.NewCollector(errors.CollectorDedupe(), errors.CollectorMaxErrors(2))
+	c.Add(errTest)
+	c.Add(errors.Wrap(errTest, errors.Prefix("prefix")))
+	require.Equal(t, 1, c.Len())
+	require.EqualError(t, c.Err(), "test error")
+
+	c.Addf("second error")
+	c.Addf("third error")
+	c.Addf("fourth error")
+	c.Add(errTest)
+	require.Equal(t, 4, c.Len())
+	require.EqualError(t, c.Err(), "multiple errors: test error · second error · and 2 more errors")
+
+	c = errors.NewCollector(errors.CollectorMaxErrors(1))
+	c.Add(errTest)
+	c.Add(errTest)
+	require.Equal(t, 2, c.Len())
+	require.Len(t, errors.Split(c.Err()), 2)
+}
+
+func TestCollector_Concurrency(t *testing.T) {
+	c := errors.NewCollector(errors.CollectorDedupe())
+	errTest := fmt.Errorf("test error")
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Addf("test error %v", i)
+			c.Add(errTest)
+			_ = c.Err()
+		}(i)
+	}
+
+	wg.Wait()
+	require.Equal(t, 101, c.Len())
+	require.Len(t, errors.Split(c.Err()), 101)
+}
@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// Metadata keys for common request-scoped values, meant to be used with ContextValue.
+var (
+	RequestIDKey = NewKey[string]("errors.RequestID")
+	TenantIDKey  = NewKey[string]("errors.TenantID")
+	UserIDKey    = NewKey[string]("errors.UserID")
+	TraceIDKey   = NewKey[string]("errors.TraceID")
+)
+
+var (
+	contextExtractorsMutex sync.RWMutex
+	contextExtractors      []ContextExtractor
+)
+
+// ContextExtractor returns a Behavior storing request-scoped values from ctx in the error metadata, or nil if ctx does
+// not carry any relevant value.
+type ContextExtractor func(ctx context.Context) Behavior
+
+// RegisterContextExtractor registers extractors that are applied by WrapCtx and ErrorfCtx, in registration order. It
+// is meant to be called at initialization time.
+func RegisterContextExtractor(extractors ...ContextExtractor) {
+	contextExtractorsMutex.Lock()
+	defer contextExtractorsMutex.Unlock()
+	contextExtractors = append(contextExtractors, extractors...)
+}
+
+// ContextValue returns a ContextExtractor that stores the value of ctx.Value(ctxKey) in the error metadata under key,
+// if it is of type T.
+func ContextValue[T any](ctxKey interface{}, key *Key[T]) ContextExtractor {
+	return func(ctx context.Context) Behavior {
+		if value, ok := ctx.Value(ctxKey).(T); ok {
+			return key.With(value)
+		}
+		return nil
+	}
+}
+
+// WrapCtx is like Wrap, but also applies the behaviors returned by the registered context extractors (see
+// RegisterContextExtractor) before the given ones.
+func WrapCtx(ctx context.Context, err error, behaviors ...Behavior) error {
+	if err == nil {
+		panic("nil error")
+	}
+
+	behaviors = append(contextBehaviors(ctx), behaviors...)
+	return Wrap(err, append(behaviors, Skip(1))...)
+}
+
+// ErrorfCtx is like Errorf, but also applies the behaviors returned by the registered context extractors (see
+// RegisterContextExtractor) before the given ones.
+func ErrorfCtx(ctx context.Context, format string, behaviorOrArg ...interface{}) error {
+	ctxBehaviors := contextBehaviors(ctx)
+	args := make([]interface{}, 0, len(ctxBehaviors)+len(behaviorOrArg)+1)
+
+	for _, behavior := range ctxBehaviors {
+		args = append(args, behavior)
+	}
+
+	args = append(append(args, behaviorOrArg...), Skip(1))
+	return Errorf(format, args...)
+}
+
+// GetRequestID extracts the request ID stored under RequestIDKey, if any.
+// It returns "" if no request ID was set.
+func GetRequestID(err error) string {
+	return RequestIDKey.GetOrDefault(err, "")
+}
+
+// GetTenantID extracts the tenant ID stored under TenantIDKey, if any.
+// It returns "" if no tenant ID was set.
+func GetTenantID(err error) string {
+	return TenantIDKey.GetOrDefault(err, "")
+}
+
+// GetUserID extracts the user ID stored under UserIDKey, if any.
+// It returns "" if no user ID was set.
+func GetUserID(err error) string {
+	return UserIDKey.GetOrDefault(err, "")
+}
+
+// GetTraceID extracts the trace ID stored under TraceIDKey, if any.
+// It returns "" if no trace ID was set.
+func GetTraceID(err error) string {
+	return TraceIDKey.GetOrDefault(err, "")
+}
+
+// contextBehaviors invokes the registered context extractors on ctx, returning the non-nil behaviors.
+func contextBehaviors(ctx context.Context) []Behavior {
+	if ctx == nil {
+		return nil
+	}
+
+	contextExtractorsMutex.RLock()
+	extractors := contextExtractors
+	contextExtractorsMutex.RUnlock()
+
+	behaviors := make([]Behavior, 0, len(extractors))
+	for _, extractor := range extractors {
+		if behavior := extractor(ctx); behavior != nil {
+			behaviors = append(behaviors, behavior)
+		}
+	}
+
+	return behaviors
+}
@@ -0,0 +1,112 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testContextKey int
+
+const (
+	testRequestIDContextKey testContextKey = iota
+	testTenantIDContextKey
+	testUserIDContextKey
+	testTraceIDContextKey
+	testAttemptContextKey
+)
+
+var testAttemptKey = errors.NewKey[int]("attempt")
+
+func init() {
+	errors.RegisterContextExtractor(
+		errors.ContextValue(testRequestIDContextKey, errors.RequestIDKey),
+		errors.ContextValue(testTenantIDContextKey, errors.TenantIDKey),
+		errors.ContextValue(testUserIDContextKey, errors.UserIDKey),
+		errors.ContextValue(testTraceIDContextKey, errors.TraceIDKey),
+		errors.ContextValue(testAttemptContextKey, testAttemptKey))
+}
+
+func ExampleWrapCtx() {
+	// func init() {
+	// 	errors.RegisterContextExtractor(
+	// 		errors.ContextValue(testRequestIDContextKey, errors.RequestIDKey))
+	// }
+
+	ctx := context.WithValue(context.Background(), testRequestIDContextKey, "request-id")
+	err := errors.WrapCtx(ctx, fmt.Errorf("test error"))
+
+	fmt.Println(errors.GetRequestID(err))
+
+	// Output:
+	// request-id
+}
+
+func TestWrapCtx(t *testing.T) {
+	require.PanicsWithValue(t, "nil error", func() {
+		_ = errors.WrapCtx(context.Background(), nil)
+	})
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, testRequestIDContextKey, "request-id")
+	ctx = context.WithValue(ctx, testTenantIDContextKey, "tenant-id")
+	ctx = context.WithValue(ctx, testUserIDContextKey, "user-id")
+	ctx = context.WithValue(ctx, testTraceIDContextKey, "trace-id")
+	ctx = context.WithValue(ctx, testAttemptContextKey, "invalid")
+
+	err := errors.WrapCtx(ctx, fmt.Errorf("test error"), errors.TenantIDKey.With("other-tenant-id"))
+	require.EqualError(t, err, "test error")
+	require.Equal(t, "request-id", errors.GetRequestID(err))
+	require.Equal(t, "other-tenant-id", errors.GetTenantID(err))
+	require.Equal(t, "user-id", errors.GetUserID(err))
+	require.Equal(t, "trace-id", errors.GetTraceID(err))
+	_, ok := testAttemptKey.Get(err)
+	require.False(t, ok)
+	require.True(t, strings.HasPrefix(errors.GetFormattedCallers(err)[0], "errors_test.TestWrapCtx"))
+
+	err = errors.WrapCtx(context.WithValue(context.Background(), testAttemptContextKey, 2), err)
+	require.Equal(t, 2, testAttemptKey.GetOrDefault(err, 0))
+	require.Equal(t, "request-id", errors.GetRequestID(err))
+
+	err = errors.WrapCtx(context.Background(), fmt.Errorf("test error"))
+	require.Equal(t, "", errors.GetRequestID(err))
+}
+
+func TestErrorfCtx(t *testing.T) {
+	ctx := context.WithValue(context.Background(), testRequestIDContextKey, "request-id")
+
+	err := errors.ErrorfCtx(ctx, "test %v", "error", errors.Prefix("prefix"))
+	require.EqualError(t, err, "prefix: test error")
+	require.Equal(t, "request-id", errors.GetRequestID(err))
+	require.True(t, strings.HasPrefix(errors.GetFormattedCallers(err)[0], "errors_test.TestErrorfCtx"))
+
+	err = errors.ErrorfCtx(nil, "test error")
+	require.EqualError(t, err, "test error")
+	require.Equal(t, "", errors.GetRequestID(err))
+}
+
+func TestWrapCtx_ContextErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := errors.WrapCtx(ctx, ctx.Err())
+	require.Equal(t, 499, errors.GetHTTPStatus(err))
+	require.Equal(t, errors.CodeCanceled, errors.GetCode(err))
+
+	p := errors.NewProblem(nil, err)
+	require.Equal(t, 499, p.Status)
+	require.Equal(t, "Client Closed Request", p.Title)
+	require.Equal(t, "Client Closed Request", p.Detail)
+
+	err = errors.ErrorfCtx(ctx, "query: %w", context.DeadlineExceeded)
+	require.Equal(t, http.StatusGatewayTimeout, errors.GetHTTPStatus(err))
+	require.Equal(t, errors.CodeDeadlineExceeded, errors.GetCode(err))
+
+	err = errors.WrapCtx(ctx, context.DeadlineExceeded, errors.HTTPStatusServiceUnavailable)
+	require.Equal(t, http.StatusServiceUnavailable, errors.GetHTTPStatus(err))
+}
@@ -18,40 +18,100 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"io"
-	"strings"
+	"reflect"
 )
 
+// wrappedError is never modified after being returned to clients, so it can be safely shared between goroutines. Wrap
+// on an already wrapped error creates a new layer, whose metadata shadows the one of its parent.
 type wrappedError struct {
 	err      error
 	metadata map[interface{}]interface{}
+	parent   *wrappedError
+}
+
+// newLayer creates a new wrapped error on top of e, with empty metadata.
+func (e *wrappedError) newLayer() *wrappedError {
+	return &wrappedError{
+		err:      e.err,
+		metadata: make(map[interface{}]interface{}),
+		parent:   e,
+	}
+}
+
+// lookup searches for the given key in the metadata of e and its parents, starting from the newest layer.
+func (e *wrappedError) lookup(key interface{}) (interface{}, bool) {
+	for ; e != nil; e = e.parent {
+		if v, ok := e.metadata[key]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// flatMetadata returns the metadata of e and its parents, merged so that newer layers take precedence.
+func (e *wrappedError) flatMetadata() map[interface{}]interface{} {
+	if e.parent == nil {
+		return e.metadata
+	}
+
+	metadata := make(map[interface{}]interface{})
+	for k, v := range e.parent.flatMetadata() {
+		metadata[k] = v
+	}
+	for k, v := range e.metadata {
+		metadata[k] = v
+	}
+	return metadata
 }
 
 // Error implements error.
 func (e *wrappedError) Error() string {
+	if inner, ok := e.err.(wrappedErrors); ok {
+		return GetPrefix(e) + renderCompound(e, inner)
+	}
 	return GetPrefix(e) + e.err.Error()
 }
 
+// Unwrap returns the original error, allowing standard library functions such as errors.Is and errors.As to inspect it.
+func (e *wrappedError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the wrapped error equals target, according to Equals. It allows errors.Is to match targets that
+// are themselves wrapped or compound errors.
+func (e *wrappedError) Is(target error) bool {
+	return Equals(e, target)
+}
+
+// As finds the first error in the chain of the original error that matches target, as in errors.As.
+func (e *wrappedError) As(target interface{}) bool {
+	return stderrors.As(e.err, target)
+}
+
 type wrappedErrors []*wrappedError
 
-// Error implements error.
+// Error implements error. The message is rendered by the global CompoundRenderer (see SetCompoundRenderer).
 func (e wrappedErrors) Error() string {
-	b := strings.Builder{}
-	b.WriteString("multiple errors: ")
+	return renderCompound(nil, e)
+}
 
+// Unwrap returns the inner errors, allowing standard library functions such as errors.Is and errors.As to inspect them.
+func (e wrappedErrors) Unwrap() []error {
+	errs := make([]error, len(e))
 	for i, err := range e {
-		if i > 0 {
-			b.WriteString(" · ")
-		}
-		b.WriteString(err.Error())
+		errs[i] = err
 	}
-
-	return b.String()
+	return errs
 }
 
 // Wrap wraps the given error, applying the given behaviors plus Callers. If the given error is already wrapped, only
 // the provided behaviors are applied. If the given error is a compound error, Wrap is applied to the last inner error.
+// Wrap never modifies the given error: re-wrapping returns a new error, which shares the unchanged data with the
+// original one. It is therefore safe to re-wrap the same error concurrently. If the given error is or wraps a sentinel
+// error (see NewSentinel), its default behaviors are applied before the given ones.
 func Wrap(err error, behaviors ...Behavior) error {
 	if err == nil {
 		panic("nil error")
@@ -60,21 +120,31 @@ func Wrap(err error, behaviors ...Behavior) error {
 	behaviors = append([]Behavior{Callers(), Skip(2)}, behaviors...)
 
 	if wErr, ok := err.(*wrappedError); ok {
+		wErr = wErr.newLayer()
 		Behaviors(behaviors...)(true, wErr)
+		finalizeCallers(wErr)
 		return wErr
 	}
 
 	if wErrs, ok := err.(wrappedErrors); ok {
+		wErrs = append(wrappedErrors{}, wErrs...)
+		wErrs[len(wErrs)-1] = wErrs[len(wErrs)-1].newLayer()
 		Behaviors(behaviors...)(true, wErrs[len(wErrs)-1])
+		finalizeCallers(wErrs[len(wErrs)-1])
 		return wErrs
 	}
 
+	if defaults := sentinelBehaviors(err); len(defaults) > 0 {
+		behaviors = append(append(behaviors[:2:2], defaults...), behaviors[2:]...)
+	}
+
 	wErr := &wrappedError{
 		err:      err,
 		metadata: make(map[interface{}]interface{}),
 	}
 
 	Behaviors(behaviors...)(false, wErr)
+	finalizeCallers(wErr)
 	return wErr
 }
 
@@ -141,6 +211,13 @@ func MaybeWrapRecover(r interface{}, behaviors ...Behavior) error {
 // Errorf formats a new error and wraps it.
 // Note: arguments implementing Behavior are applied on wrapping, the others are passed to fmt.Errorf().
 func Errorf(format string, behaviorOrArg ...interface{}) error {
+	behaviors, args := splitBehaviorsAndArgs(behaviorOrArg)
+	behaviors = append(append([]Behavior{formatKey.With(format)}, behaviors...), Skip(1))
+	return Wrap(fmt.Errorf(format, args...), behaviors...)
+}
+
+// splitBehaviorsAndArgs separates the arguments implementing Behavior from the others.
+func splitBehaviorsAndArgs(behaviorOrArg []interface{}) ([]Behavior, []interface{}) {
 	behaviors := make([]Behavior, 0, len(behaviorOrArg))
 	args := make([]interface{}, 0, len(behaviorOrArg))
 
@@ -152,8 +229,7 @@ func Errorf(format string, behaviorOrArg ...interface{}) error {
 		}
 	}
 
-	behaviors = append(behaviors, Skip(1))
-	return Wrap(fmt.Errorf(format, args...), behaviors...)
+	return behaviors, args
 }
 
 // MustErrorf is like Errorf but panics instead of returning the error.
@@ -177,11 +253,12 @@ func Append(existingErr, newErr error) error {
 
 	var wErrs wrappedErrors
 
+	// The inner errors are always copied to a new slice, so that compound errors never share a backing array.
 	switch err := existingErr.(type) {
 	case *wrappedError:
 		wErrs = wrappedErrors{err}
 	case wrappedErrors:
-		wErrs = err
+		wErrs = append(make(wrappedErrors, 0, len(err)+1), err...)
 	default:
 		wErrs = wrappedErrors{Wrap(err).(*wrappedError)}
 	}
@@ -266,9 +343,50 @@ func Unwrap(err error) error {
 	return err
 }
 
+// equalsChain reports whether err, or any error in its chain, equals cause. The chain is followed through Unwrap
+// methods, as in errors.Is.
+func equalsChain(err, cause error) bool {
+	for err != nil {
+		if isEqual(err, cause) {
+			return true
+		}
+
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				if equalsChain(inner, cause) {
+					return true
+				}
+			}
+			return false
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// isEqual compares two unwrapped errors using ==, returning false instead of panicking if they share an uncomparable
+// type, as errors.Is does.
+func isEqual(err, cause error) bool {
+	if err == nil || cause == nil {
+		return err == cause
+	}
+	if t := reflect.TypeOf(err); t != reflect.TypeOf(cause) || !t.Comparable() {
+		return false
+	}
+	return err == cause
+}
+
 // Equals returns true if the given error equals any of the given causes. If the given error is a compound error, Equals
 // returns true if any of the inner errors equals any of the given causes. Causes can also be compound errors, in which
-// case inner errors are flattened out. Both the given error and causes are unwrapped before checking for equality.
+// case inner errors are flattened out. Nodes (see Nest) are treated like compound errors, recursively. Both the given
+// error and causes are unwrapped before checking for equality, and the chain of the given error is followed as in
+// errors.Is, e.g. through errors created by Errorf with the %w verb. Errors of uncomparable types never equal each
+// other.
 func Equals(err error, causes ...error) bool {
 	if wErrs, ok := err.(wrappedErrors); ok {
 		for _, wErr := range wErrs {
@@ -282,15 +400,17 @@ func Equals(err error, causes ...error) bool {
 
 	err = Unwrap(err)
 
+	if wErrs, ok := err.(wrappedErrors); ok {
+		return Equals(wErrs, causes...)
+	}
+
 	for _, cause := range causes {
-		if wErrs, ok := cause.(wrappedErrors); ok {
-			for _, cause := range wErrs {
-				if err == Unwrap(cause) {
-					return true
-				}
+		if wErrs := children(cause); wErrs != nil {
+			if Equals(err, wErrs.Unwrap()...) {
+				return true
 			}
 		} else {
-			if err == Unwrap(cause) {
+			if equalsChain(err, Unwrap(cause)) {
 				return true
 			}
 		}
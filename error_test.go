@@ -1,6 +1,7 @@
 package errors_test
 
 import (
+	stderrors "errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -618,5 +619,162 @@ func TestEquals(t *testing.T) {
 	require.True(t, errors.Equals(errs, err))
 	require.True(t, errors.Equals(err, errs))
 	require.False(t, errors.Equals(errs, fmt.Errorf("third error")))
+	require.True(t, errors.Equals(errors.Errorf("outer: %w", err), err))
+	require.True(t, errors.Equals(fmt.Errorf("outer: %w", errs), err))
+	require.True(t, errors.Equals(stderrors.Join(io.EOF, fmt.Errorf("outer: %w", err)), err))
+	require.False(t, errors.Equals(errors.Errorf("outer: %v", err), err))
+}
+
+func TestEquals_Uncomparable(t *testing.T) {
+	err := errors.Wrap(sliceError{values: []string{"value"}})
+	require.False(t, errors.Equals(err, sliceError{}))
+	require.False(t, errors.Equals(err, err))
+	require.False(t, stderrors.Is(err, sliceError{}))
+	require.False(t, stderrors.Is(errors.Append(err, io.EOF), sliceError{}))
+	require.True(t, stderrors.Is(errors.Append(err, io.EOF), io.EOF))
+}
+
+type testError struct {
+	msg string
+}
+
+// Error implements error.
+func (e *testError) Error() string {
+	return e.msg
+}
 
+type sliceError struct {
+	values []string
+}
+
+// Error implements error.
+func (e sliceError) Error() string {
+	return fmt.Sprint(e.values)
+}
+
+func ExampleWrap_standardLibrary() {
+	err := errors.Wrap(io.EOF, errors.Prefix("read failed"))
+
+	fmt.Println(stderrors.Is(err, io.EOF))
+	fmt.Println(stderrors.Unwrap(err) == io.EOF)
+
+	// Output:
+	// true
+	// true
+}
+
+func TestStandardLibrary_Is(t *testing.T) {
+	err := errors.Wrap(io.EOF, errors.Prefix("prefix"))
+	require.True(t, stderrors.Is(err, io.EOF))
+	require.True(t, stderrors.Is(err, errors.Wrap(io.EOF)))
+	require.False(t, stderrors.Is(err, io.ErrUnexpectedEOF))
+	require.True(t, stderrors.Is(fmt.Errorf("outer: %w", err), io.EOF))
+	require.True(t, stderrors.Is(errors.Errorf("outer: %w", io.EOF), io.EOF))
+
+	errs := errors.Append(io.EOF, io.ErrUnexpectedEOF)
+	require.True(t, stderrors.Is(errs, io.EOF))
+	require.True(t, stderrors.Is(errs, io.ErrUnexpectedEOF))
+	require.False(t, stderrors.Is(errs, io.ErrClosedPipe))
+	require.True(t, stderrors.Is(stderrors.Join(errs, io.ErrClosedPipe), io.ErrUnexpectedEOF))
+}
+
+func TestStandardLibrary_As(t *testing.T) {
+	var tErr *testError
+
+	err := errors.Wrap(&testError{msg: "test error"}, errors.Prefix("prefix"))
+	require.True(t, stderrors.As(err, &tErr))
+	require.Equal(t, "test error", tErr.msg)
+
+	tErr = nil
+	err = errors.Wrap(fmt.Errorf("outer: %w", &testError{msg: "inner error"}))
+	require.True(t, stderrors.As(err, &tErr))
+	require.Equal(t, "inner error", tErr.msg)
+
+	tErr = nil
+	errs := errors.Append(fmt.Errorf("first error"), &testError{msg: "second error"})
+	require.True(t, stderrors.As(errs, &tErr))
+	require.Equal(t, "second error", tErr.msg)
+
+	require.False(t, stderrors.As(errors.Errorf("test error"), &tErr))
+}
+
+func TestStandardLibrary_Unwrap(t *testing.T) {
+	err := fmt.Errorf("test error")
+	require.Equal(t, err, stderrors.Unwrap(errors.Wrap(err)))
+
+	errs := errors.Append(io.EOF, io.ErrUnexpectedEOF)
+	unwrapped := errs.(interface{ Unwrap() []error }).Unwrap()
+	require.Len(t, unwrapped, 2)
+	require.Equal(t, io.EOF, errors.Unwrap(unwrapped[0]))
+	require.Equal(t, io.ErrUnexpectedEOF, errors.Unwrap(unwrapped[1]))
+}
+
+func TestWrap_Immutable(t *testing.T) {
+	err := errors.Errorf("test error", errors.Prefix("first"), errors.Metadata("key", "value"))
+	err2 := errors.Wrap(err, errors.Prefix("second"), errors.Metadata("key", "changed"))
+	require.Equal(t, "first: test error", err.Error())
+	require.Equal(t, "value", errors.GetMetadata(err, "key"))
+	require.Equal(t, "second: first: test error", err2.Error())
+	require.Equal(t, "changed", errors.GetMetadata(err2, "key"))
+	require.Equal(t, errors.GetCallers(err), errors.GetCallers(err2))
+	require.True(t, errors.Equals(err, err2))
+
+	errs := errors.Append(errors.Errorf("first error"), errors.Errorf("second error"))
+	errs2 := errors.Wrap(errs, errors.Prefix("prefix"))
+	require.Equal(t, "multiple errors: first error · second error", errs.Error())
+	require.Equal(t, "multiple errors: first error · prefix: second error", errs2.Error())
+}
+
+func TestAppend_Immutable(t *testing.T) {
+	errs := errors.Append(errors.Errorf("first error"), errors.Errorf("second error"))
+	errs = errors.Append(errs, errors.Errorf("third error"))
+	errs1 := errors.Append(errs, errors.Errorf("fourth error"))
+	errs2 := errors.Append(errs, errors.Errorf("other error"))
+	require.Equal(t, "multiple errors: first error · second error · third error", errs.Error())
+	require.Equal(t, "multiple errors: first error · second error · third error · fourth error", errs1.Error())
+	require.Equal(t, "multiple errors: first error · second error · third error · other error", errs2.Error())
+}
+
+func TestConcurrency(t *testing.T) {
+	type result struct {
+		i          int
+		wErr       error
+		wErrs      error
+		errKey     interface{}
+		errsKey    interface{}
+		errsString string
+		formatted  string
+	}
+
+	err := errors.Errorf("shared error", errors.Metadata("key", "value"))
+	errs := errors.Append(err, errors.Errorf("other error"))
+	results := make(chan *result)
+
+	for i := 0; i < 16; i++ {
+		go func(i int) {
+			wErrs := errors.Wrap(errs, errors.Prefix("goroutine %v", i))
+			wErrs = errors.Append(wErrs, errors.Errorf("error %v", i))
+
+			results <- &result{
+				i:          i,
+				wErr:       errors.Wrap(err, errors.Prefix("goroutine %v", i), errors.Metadata("key", i)),
+				wErrs:      wErrs,
+				errKey:     errors.GetMetadata(err, "key"),
+				errsKey:    errors.GetMetadata(errs, "key"),
+				errsString: errs.Error(),
+				formatted:  fmt.Sprintf("%+v", wErrs),
+			}
+		}(i)
+	}
+
+	for n := 0; n < 16; n++ {
+		r := <-results
+		require.Equal(t, fmt.Sprintf("goroutine %v: shared error", r.i), r.wErr.Error())
+		require.Equal(t, r.i, errors.GetMetadata(r.wErr, "key"))
+		require.Equal(t, "value", r.errKey)
+		require.Equal(t, fmt.Sprintf("multiple errors: shared error · goroutine %v: other error · error %v", r.i, r.i), r.wErrs.Error())
+		require.Equal(t, "multiple errors: shared error · other error", r.errsString)
+		require.Equal(t, "value", r.errsKey)
+		require.NotEmpty(t, r.formatted)
+	}
 }
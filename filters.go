@@ -0,0 +1,191 @@
+package errors
+
+import (
+	"strings"
+	"sync"
+)
+
+// FrameFilter transforms a stack trace, e.g. by dropping or collapsing frames. Filters must preserve the relative order
+// of the frames they keep.
+type FrameFilter func(frames []Frame) []Frame
+
+var (
+	frameFiltersKey        = NewKey[[]FrameFilter]("errors.FrameFilters")
+	captureFrameFiltersKey = NewKey[[]FrameFilter]("errors.CaptureFrameFilters")
+
+	frameFiltersMutex   sync.RWMutex
+	frameFilters        []FrameFilter
+	captureFrameFilters []FrameFilter
+)
+
+// SetFrameFilters sets the filters applied globally at format time, i.e. by FilteredFrames and GetFormattedCallers.
+// No filters are applied by default.
+func SetFrameFilters(filters ...FrameFilter) {
+	frameFiltersMutex.Lock()
+	defer frameFiltersMutex.Unlock()
+	frameFilters = filters
+}
+
+// SetCaptureFrameFilters sets the filters applied globally at capture time, i.e. when a new error is wrapped. Frames
+// dropped at capture time are never stored. No filters are applied by default.
+func SetCaptureFrameFilters(filters ...FrameFilter) {
+	frameFiltersMutex.Lock()
+	defer frameFiltersMutex.Unlock()
+	captureFrameFilters = filters
+}
+
+// FrameFilters returns a Behavior that stores filters in the error metadata, which are applied at format time after the
+// global ones.
+func FrameFilters(filters ...FrameFilter) Behavior {
+	return func(doubleWrap bool, err error) {
+		existing, _ := frameFiltersKey.Get(err)
+		frameFiltersKey.With(append(append([]FrameFilter{}, existing...), filters...))(doubleWrap, err)
+	}
+}
+
+// CaptureFrameFilters returns a Behavior that applies filters to the stack trace at capture time, after the global
+// ones. It only has effect when an error is wrapped for the first time.
+func CaptureFrameFilters(filters ...FrameFilter) Behavior {
+	return func(doubleWrap bool, err error) {
+		existing, _ := captureFrameFiltersKey.Get(err)
+		captureFrameFiltersKey.With(append(append([]FrameFilter{}, existing...), filters...))(doubleWrap, err)
+	}
+}
+
+// FilteredFrames is like Frames, but applies the global filters and the ones stored in the error metadata.
+func FilteredFrames(err error) []Frame {
+	frames := Frames(err)
+	if frames == nil {
+		return nil
+	}
+
+	frameFiltersMutex.RLock()
+	filters := frameFilters
+	frameFiltersMutex.RUnlock()
+
+	errFilters, _ := frameFiltersKey.Get(err)
+	return ApplyFrameFilters(ApplyFrameFilters(frames, filters...), errFilters...)
+}
+
+// ApplyFrameFilters applies the given filters to frames, in order.
+func ApplyFrameFilters(frames []Frame, filters ...FrameFilter) []Frame {
+	for _, filter := range filters {
+		frames = filter(frames)
+	}
+	return frames
+}
+
+// DropRuntimeFrames returns a FrameFilter that drops frames belonging to the runtime package.
+func DropRuntimeFrames() FrameFilter {
+	return DropPackages("runtime")
+}
+
+// DropPackages returns a FrameFilter that drops frames belonging to packages whose import path equals or starts with
+// one of the given prefixes, e.g. "net/http" drops frames in both "net/http" and "net/http/httputil".
+func DropPackages(prefixes ...string) FrameFilter {
+	return func(frames []Frame) []Frame {
+		filtered := make([]Frame, 0, len(frames))
+
+		for _, frame := range frames {
+			if !matchesPackage(frame.Package, prefixes) {
+				filtered = append(filtered, frame)
+			}
+		}
+
+		return filtered
+	}
+}
+
+// KeepModuleFrames returns a FrameFilter that only keeps frames with origin FrameOriginModule.
+func KeepModuleFrames() FrameFilter {
+	return func(frames []Frame) []Frame {
+		filtered := make([]Frame, 0, len(frames))
+
+		for _, frame := range frames {
+			if frame.Origin == FrameOriginModule {
+				filtered = append(filtered, frame)
+			}
+		}
+
+		return filtered
+	}
+}
+
+// CollapseRecursion returns a FrameFilter that collapses consecutive frames of the same function (i.e. direct
+// recursion) into the first one.
+func CollapseRecursion() FrameFilter {
+	return func(frames []Frame) []Frame {
+		filtered := make([]Frame, 0, len(frames))
+
+		for i, frame := range frames {
+			if i == 0 || frame.Function != frames[i-1].Function {
+				filtered = append(filtered, frame)
+			}
+		}
+
+		return filtered
+	}
+}
+
+// matchesPackage returns true if pkg equals or is a sub-package of one of the given prefixes.
+func matchesPackage(pkg string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if pkg == prefix || strings.HasPrefix(pkg, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// applyCaptureFrameFilters applies the global and error capture time filters to the stack trace stored in the current
+// layer of e, if any.
+func applyCaptureFrameFilters(e *wrappedError) {
+	frameFiltersMutex.RLock()
+	filters := captureFrameFilters
+	frameFiltersMutex.RUnlock()
+
+	if errFilters, ok := e.metadata[captureFrameFiltersKey].([]FrameFilter); ok {
+		filters = append(append([]FrameFilter{}, filters...), errFilters...)
+	}
+
+	if len(filters) == 0 {
+		return
+	}
+
+	if callers, ok := e.metadata[callersKey].([]uintptr); ok {
+		e.metadata[callersKey] = filterCallers(callers, filters)
+	}
+}
+
+// filterCallers applies filters to the frames corresponding to callers, and returns the callers whose frames are kept.
+func filterCallers(callers []uintptr, filters []FrameFilter) []uintptr {
+	frames := make([]Frame, 0, len(callers))
+	indexes := make([]int, 0, len(callers))
+
+	for i, pc := range callers {
+		for _, frame := range CallersFrames([]uintptr{pc}) {
+			frames = append(frames, frame)
+			indexes = append(indexes, i)
+		}
+	}
+
+	filtered := ApplyFrameFilters(frames, filters...)
+	filteredCallers := make([]uintptr, 0, len(filtered))
+	lastIndex := -1
+	j := 0
+
+	for _, frame := range filtered {
+		for ; j < len(frames); j++ {
+			if frames[j] == frame {
+				if indexes[j] != lastIndex {
+					filteredCallers = append(filteredCallers, callers[indexes[j]])
+					lastIndex = indexes[j]
+				}
+				j++
+				break
+			}
+		}
+	}
+
+	return filteredCallers
+}
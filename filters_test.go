@@ -0,0 +1,96 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func recurse(n int, fn func() error) error {
+	if n == 0 {
+		return fn()
+	}
+	return recurse(n-1, fn)
+}
+
+func frameFunctions(frames []errors.Frame) []string {
+	functions := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		functions = append(functions, frame.ShortFunction())
+	}
+	return functions
+}
+
+func ExampleFrameFilters() {
+	err := errors.Errorf("test error", errors.FrameFilters(errors.KeepModuleFrames()))
+
+	for _, caller := range errors.GetFormattedCallers(err) {
+		fmt.Println(strings.Fields(caller)[0])
+	}
+
+	// Output:
+	// errors_test.ExampleFrameFilters
+}
+
+func TestFrameFilters(t *testing.T) {
+	err := recurse(3, func() error { return errors.Errorf("test error") })
+	require.Equal(t, []string{
+		"errors_test.TestFrameFilters.func1",
+		"errors_test.recurse",
+		"errors_test.recurse",
+		"errors_test.recurse",
+		"errors_test.recurse",
+		"errors_test.TestFrameFilters",
+		"testing.tRunner",
+		"runtime.goexit",
+	}, frameFunctions(errors.FilteredFrames(err)))
+
+	err = errors.Wrap(err, errors.FrameFilters(errors.CollapseRecursion(), errors.DropRuntimeFrames()))
+	require.Equal(t, []string{
+		"errors_test.TestFrameFilters.func1",
+		"errors_test.recurse",
+		"errors_test.TestFrameFilters",
+		"testing.tRunner",
+	}, frameFunctions(errors.FilteredFrames(err)))
+	require.Len(t, errors.Frames(err), 8)
+
+	err = errors.Wrap(err, errors.FrameFilters(errors.DropPackages("testing")))
+	require.Len(t, errors.FilteredFrames(err), 3)
+	require.Len(t, errors.GetFormattedCallers(err), 3)
+	require.NotContains(t, fmt.Sprintf("%+v", err), "errors.FrameFilters")
+
+	require.Nil(t, errors.FilteredFrames(fmt.Errorf("test error")))
+}
+
+func TestSetFrameFilters(t *testing.T) {
+	errors.SetFrameFilters(errors.DropRuntimeFrames(), errors.DropPackages("testing"))
+	defer errors.SetFrameFilters()
+
+	err := errors.Errorf("test error", errors.FrameFilters(errors.KeepModuleFrames()))
+	require.Equal(t, []string{"errors_test.TestSetFrameFilters"}, frameFunctions(errors.FilteredFrames(err)))
+
+	err = errors.Errorf("test error")
+	require.Equal(t, []string{"errors_test.TestSetFrameFilters"}, frameFunctions(errors.FilteredFrames(err)))
+	require.Len(t, errors.Frames(err), 3)
+}
+
+func TestCaptureFrameFilters(t *testing.T) {
+	err := recurse(2, func() error {
+		return errors.Errorf("test error", errors.CaptureFrameFilters(errors.CollapseRecursion(), errors.KeepModuleFrames()))
+	})
+	require.Equal(t, []string{
+		"errors_test.TestCaptureFrameFilters.func1",
+		"errors_test.recurse",
+		"errors_test.TestCaptureFrameFilters",
+	}, frameFunctions(errors.Frames(err)))
+	require.Len(t, errors.GetCallers(err), 3)
+
+	errors.SetCaptureFrameFilters(errors.DropRuntimeFrames())
+	defer errors.SetCaptureFrameFilters()
+
+	err = errors.Errorf("test error")
+	require.Equal(t, []string{"errors_test.TestCaptureFrameFilters", "testing.tRunner"}, frameFunctions(errors.Frames(err)))
+}
@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultFingerprintFrames is the default number of stack frames taken into account by Fingerprint.
+const DefaultFingerprintFrames = 5
+
+var fingerprintFrames atomic.Int64
+
+func init() {
+	fingerprintFrames.Store(DefaultFingerprintFrames)
+}
+
+// SetFingerprintFrames sets the number of stack frames taken into account by Fingerprint. A value of 0 makes
+// fingerprints independent of the stack trace.
+func SetFingerprintFrames(frames int) {
+	if frames < 0 {
+		panic("invalid frames")
+	}
+	fingerprintFrames.Store(int64(frames))
+}
+
+// GetFingerprintFrames returns the number of stack frames taken into account by Fingerprint.
+func GetFingerprintFrames() int {
+	return int(fingerprintFrames.Load())
+}
+
+var (
+	formatKey      = NewKey[string]("errors.Format")
+	fingerprintKey = NewKey[string]("errors.Fingerprint")
+
+	// variableTokens matches UUIDs, hexadecimal and decimal numbers, which are replaced when normalizing messages.
+	variableTokens = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}|0x[0-9a-f]+|[0-9]+`)
+)
+
+// FingerprintKey returns a Behavior that overrides the fingerprint of the error (see Fingerprint) with one computed
+// from the given parts only.
+func FingerprintKey(parts ...string) Behavior {
+	return fingerprintKey.With(hashFingerprint(parts...))
+}
+
+// Fingerprint returns a stable hash meant for grouping similar errors, e.g. in an error tracker. Unless overridden by
+// FingerprintKey, it is computed from the type of the original error, the format string (for errors created by Errorf)
+// or the message with numbers and UUIDs stripped (for other errors), and the function names of the first frames (see
+// SetFingerprintFrames) of the stack trace in the main module (see FrameOriginModule), or of the stack trace if none is
+// in the main module. Prefixes and metadata are ignored. If err is a compound error or a node (see Nest), the
+// fingerprint is computed from the fingerprints of its inner errors.
+func Fingerprint(err error) string {
+	if err == nil {
+		panic("nil error")
+	}
+
+	if _, ok := err.(wrappedErrors); !ok {
+		if fingerprint, ok := fingerprintKey.Get(err); ok {
+			return fingerprint
+		}
+	}
+
+	if inner := children(err); inner != nil {
+		parts := make([]string, 0, len(inner))
+		for _, wErr := range inner {
+			parts = append(parts, Fingerprint(wErr))
+		}
+		return hashFingerprint(parts...)
+	}
+
+	original := Unwrap(err)
+	parts := []string{reflect.TypeOf(original).String()}
+
+	if format, ok := formatKey.Get(err); ok {
+		parts = append(parts, format)
+	} else {
+		parts = append(parts, variableTokens.ReplaceAllString(original.Error(), "#"))
+	}
+
+	return hashFingerprint(append(parts, fingerprintFunctions(Frames(err))...)...)
+}
+
+// fingerprintFunctions returns the function names of the first frames in the main module, or of the first frames if
+// none is in the main module, up to the amount returned by GetFingerprintFrames.
+func fingerprintFunctions(frames []Frame) []string {
+	maxFrames := GetFingerprintFrames()
+	functions := make([]string, 0, maxFrames)
+
+	for _, frame := range frames {
+		if len(functions) >= maxFrames {
+			break
+		}
+		if frame.Origin == FrameOriginModule {
+			functions = append(functions, frame.Function)
+		}
+	}
+
+	if len(functions) > 0 || maxFrames == 0 {
+		return functions
+	}
+
+	for i := 0; i < len(frames) && i < maxFrames; i++ {
+		functions = append(functions, frames[i].Function)
+	}
+
+	return functions
+}
+
+// hashFingerprint hashes the given parts into a fingerprint.
+func hashFingerprint(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,76 @@
+package errors_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func findUser(id int) error {
+	return errors.Errorf("user %v not found", id, errors.HTTPStatusNotFound)
+}
+
+func findOrder(id int) error {
+	return errors.Errorf("order %v not found", id, errors.HTTPStatusNotFound)
+}
+
+func ExampleFingerprint() {
+	fmt.Println(errors.Fingerprint(findUser(1)) == errors.Fingerprint(findUser(2)))
+	fmt.Println(errors.Fingerprint(findUser(1)) == errors.Fingerprint(findOrder(1)))
+
+	// Output:
+	// true
+	// false
+}
+
+func TestFingerprint(t *testing.T) {
+	require.PanicsWithValue(t, "nil error", func() {
+		errors.Fingerprint(nil)
+	})
+
+	fingerprint := errors.Fingerprint(findUser(1))
+	require.Len(t, fingerprint, 32)
+	require.Equal(t, fingerprint, errors.Fingerprint(findUser(1234)))
+	require.Equal(t, fingerprint, errors.Fingerprint(errors.Wrap(findUser(1), errors.Prefix("prefix"), errors.Metadata("k", "v"))))
+	require.NotEqual(t, fingerprint, errors.Fingerprint(findOrder(1)))
+	require.NotEqual(t, fingerprint, errors.Fingerprint(errors.Errorf("user %v not found", 1)))
+
+	require.Equal(t,
+		errors.Fingerprint(fmt.Errorf("user 1 not found, request f47ac10b-58cc-4372-a567-0e02b2c3d479")),
+		errors.Fingerprint(fmt.Errorf("user 2 not found, request 6ba7b810-9dad-11d1-80b4-00c04fd430c8")))
+	require.NotEqual(t,
+		errors.Fingerprint(fmt.Errorf("user 1 not found")),
+		errors.Fingerprint(fmt.Errorf("order 1 not found")))
+
+	custom := errors.Fingerprint(errors.Wrap(findUser(1), errors.FingerprintKey("custom")))
+	require.Equal(t, custom, errors.Fingerprint(errors.Wrap(findOrder(2), errors.FingerprintKey("custom"))))
+	require.NotEqual(t, custom, errors.Fingerprint(errors.Wrap(findUser(1), errors.FingerprintKey("other"))))
+	require.NotEqual(t, custom, fingerprint)
+}
+
+func TestSetFingerprintFrames(t *testing.T) {
+	defer errors.SetFingerprintFrames(errors.DefaultFingerprintFrames)
+	require.Equal(t, errors.DefaultFingerprintFrames, errors.GetFingerprintFrames())
+	require.PanicsWithValue(t, "invalid frames", func() {
+		errors.SetFingerprintFrames(-1)
+	})
+
+	errors.SetFingerprintFrames(0)
+	require.Equal(t, 0, errors.GetFingerprintFrames())
+	require.Equal(t, errors.Fingerprint(findUser(1)), errors.Fingerprint(errors.Errorf("user %v not found", 1)))
+
+	errors.SetFingerprintFrames(1)
+	require.NotEqual(t, errors.Fingerprint(findUser(1)), errors.Fingerprint(errors.Errorf("user %v not found", 1)))
+}
+
+func TestFingerprint_Compound(t *testing.T) {
+	err1 := errors.Append(findUser(1), findOrder(1))
+	err2 := errors.Append(findUser(2), findOrder(2))
+	err3 := errors.Append(findOrder(1), findUser(1))
+
+	require.Equal(t, errors.Fingerprint(err1), errors.Fingerprint(err2))
+	require.NotEqual(t, errors.Fingerprint(err1), errors.Fingerprint(err3))
+	require.NotEqual(t, errors.Fingerprint(err1), errors.Fingerprint(findUser(1)))
+}
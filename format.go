@@ -0,0 +1,163 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Format implements fmt.Formatter. The %s and %v verbs print the error message, %q prints it quoted, and %+v prints
+// the full diagnostic output: message, prefix, public message, HTTP status, metadata, stack trace and, for nodes (see
+// Nest), the diagnostic output of each child as a nested numbered section.
+func (e *wrappedError) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		formatVerbose(s, e, "")
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// Format implements fmt.Formatter. The %s and %v verbs print the error message, %q prints it quoted, and %+v prints
+// the full diagnostic output of each inner error as a numbered section.
+func (e wrappedErrors) Format(s fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, e.Error())
+		for i, err := range e {
+			fmt.Fprintf(s, "\n[%v/%v] ", i+1, len(e))
+			formatVerbose(s, err, "  ")
+		}
+	case verb == 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		io.WriteString(s, e.Error())
+	}
+}
+
+// formatVerbose writes the full diagnostic output for e, indenting all lines but the first.
+func formatVerbose(w io.Writer, e *wrappedError, indent string) {
+	io.WriteString(w, e.Error())
+
+	if prefix := GetPrefix(e); prefix != "" {
+		fmt.Fprintf(w, "\n%v  prefix: %v", indent, strings.TrimSuffix(prefix, ": "))
+	}
+	if message := GetPublicMessage(e); message != "" {
+		fmt.Fprintf(w, "\n%v  public message: %v", indent, message)
+	}
+	if status := GetHTTPStatus(e); status != 0 {
+		fmt.Fprintf(w, "\n%v  http status: %v", indent, status)
+	}
+
+	if metadata := formatMetadata(e); len(metadata) > 0 {
+		fmt.Fprintf(w, "\n%v  metadata:", indent)
+		for _, entry := range metadata {
+			fmt.Fprintf(w, "\n%v    %v", indent, entry)
+		}
+	}
+
+	if callers := GetFormattedCallers(e); len(callers) > 0 {
+		fmt.Fprintf(w, "\n%v  stack:", indent)
+		for _, caller := range callers {
+			fmt.Fprintf(w, "\n%v    %v", indent, caller)
+		}
+	}
+
+	if layers := Layers(e); len(layers) > 1 {
+		fmt.Fprintf(w, "\n%v  layers:", indent)
+		for i, layer := range layers {
+			fmt.Fprintf(w, "\n%v    [%v] %v", indent, i+1, layer.Caller())
+			if layer.Prefix != "" {
+				fmt.Fprintf(w, "\n%v      prefix: %v", indent, strings.TrimSuffix(layer.Prefix, ": "))
+			}
+			for _, entry := range formatEntries(layer.Metadata) {
+				fmt.Fprintf(w, "\n%v      %v", indent, entry)
+			}
+		}
+	}
+
+	if inner := children(e); inner != nil {
+		fmt.Fprintf(w, "\n%v  errors:", indent)
+		for i, err := range inner {
+			fmt.Fprintf(w, "\n%v  [%v/%v] ", indent, i+1, len(inner))
+			formatVerbose(w, err, indent+"    ")
+		}
+	}
+}
+
+// formatMetadata returns the sorted "key: value" representations of the user-defined metadata stored in e.
+func formatMetadata(e *wrappedError) []string {
+	return formatEntries(userMetadata(e))
+}
+
+// formatEntries returns the sorted "key: value" representations of the given metadata.
+func formatEntries(metadata map[interface{}]interface{}) []string {
+	entries := make([]string, 0, len(metadata))
+
+	for key, value := range metadata {
+		entries = append(entries, fmt.Sprintf("%v: %v", formatMetadataKey(key), value))
+	}
+
+	sort.Strings(entries)
+	return entries
+}
+
+// userMetadata returns the metadata stored in e, excluding the keys used by built-in behaviors.
+func userMetadata(e *wrappedError) map[interface{}]interface{} {
+	flatMetadata := e.flatMetadata()
+	metadata := make(map[interface{}]interface{}, len(flatMetadata))
+
+	for key, value := range flatMetadata {
+		if !isBuiltInKey(key) {
+			metadata[key] = value
+		}
+	}
+
+	return metadata
+}
+
+// isBuiltInKey returns true if key is used by one of the built-in behaviors, which are rendered separately.
+func isBuiltInKey(key interface{}) bool {
+	switch key {
+	case prefixKey, publicMessageKey, httpStatusKey:
+		return true
+	default:
+		return isInternalKey(key)
+	}
+}
+
+// isInternalKey returns true if key is used to store stack traces, their configuration, or the format string used to
+// create the error, which are never rendered as metadata.
+func isInternalKey(key interface{}) bool {
+	switch key {
+	case callersKey, layerCallersKey, formattedCallersKey, frameFiltersKey, captureFrameFiltersKey, formatKey,
+		compoundRendererKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatMetadataKey returns a human-readable representation of a metadata key. Keys derived from functions (via
+// reflect.ValueOf) are represented by the function name, keys derived from types (via reflect.TypeOf) by the type name.
+func formatMetadataKey(key interface{}) string {
+	switch key := key.(type) {
+	case reflect.Value:
+		if key.Kind() == reflect.Func {
+			if fn := runtime.FuncForPC(key.Pointer()); fn != nil {
+				return filepath.Base(fn.Name())
+			}
+		}
+		return key.String()
+	case reflect.Type:
+		return key.String()
+	default:
+		return fmt.Sprintf("%v", key)
+	}
+}
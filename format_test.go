@@ -0,0 +1,77 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleWrap_format() {
+	err := errors.Errorf("test error", errors.Prefix("prefix"))
+
+	fmt.Printf("%s\n", err)
+	fmt.Printf("%v\n", err)
+	fmt.Printf("%q\n", err)
+
+	// Output:
+	// prefix: test error
+	// prefix: test error
+	// "prefix: test error"
+}
+
+func TestFormat(t *testing.T) {
+	err := errors.Errorf("test error",
+		errors.Prefix("first"),
+		errors.Prefix("second"),
+		errors.PublicMessage("public message"),
+		errors.HTTPStatus(http.StatusBadRequest),
+		errors.Metadata("key", "value"),
+		MyValue("my value"))
+
+	require.Equal(t, "second: first: test error", fmt.Sprintf("%s", err))
+	require.Equal(t, "second: first: test error", fmt.Sprintf("%v", err))
+	require.Equal(t, `"second: first: test error"`, fmt.Sprintf("%q", err))
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	require.True(t, len(lines) > 9)
+	require.Equal(t, []string{
+		"second: first: test error",
+		"  prefix: second: first",
+		"  public message: public message",
+		"  http status: 400",
+		"  metadata:",
+		"    func(string) errors.Behavior: my value",
+		"    key: value",
+		"  stack:",
+	}, lines[:8])
+	require.True(t, strings.HasPrefix(lines[8], "    errors_test.TestFormat"))
+}
+
+func TestFormat_Minimal(t *testing.T) {
+	err := errors.Wrap(fmt.Errorf("test error"))
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	require.Equal(t, []string{"test error", "  stack:"}, lines[:2])
+	require.True(t, strings.HasPrefix(lines[2], "    errors_test.TestFormat_Minimal"))
+}
+
+func TestFormat_Compound(t *testing.T) {
+	err := errors.Append(
+		errors.Errorf("first error", errors.HTTPStatus(http.StatusNotFound)),
+		errors.Errorf("second error", errors.Metadata(reflect.TypeOf(0), 1)))
+
+	require.Equal(t, "multiple errors: first error · second error", fmt.Sprintf("%s", err))
+	require.Equal(t, "multiple errors: first error · second error", fmt.Sprintf("%v", err))
+	require.Equal(t, `"multiple errors: first error · second error"`, fmt.Sprintf("%q", err))
+
+	formatted := fmt.Sprintf("%+v", err)
+	require.True(t, strings.HasPrefix(formatted, "multiple errors: first error · second error\n[1/2] first error\n"))
+	require.Contains(t, formatted, "\n    http status: 404\n")
+	require.Contains(t, formatted, "\n[2/2] second error\n")
+	require.Contains(t, formatted, "\n    metadata:\n      int: 1\n")
+	require.Contains(t, formatted, "\n    stack:\n      errors_test.TestFormat_Compound")
+}
@@ -0,0 +1,212 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+var modulePath atomic.Pointer[string]
+
+func init() {
+	path := ""
+	if info, ok := debug.ReadBuildInfo(); ok {
+		path = info.Main.Path
+	}
+	modulePath.Store(&path)
+}
+
+// SetModulePath sets the path of the main module, used to classify frames as FrameOriginModule. It defaults to the
+// path found in the build information of the running binary.
+func SetModulePath(path string) {
+	modulePath.Store(&path)
+}
+
+// GetModulePath returns the path of the main module, used to classify frames as FrameOriginModule.
+func GetModulePath() string {
+	return *modulePath.Load()
+}
+
+// FrameOrigin classifies a Frame based on the package it belongs to.
+type FrameOrigin int
+
+// Known frame origins.
+const (
+	// FrameOriginModule denotes a frame in the main module (see SetModulePath).
+	FrameOriginModule FrameOrigin = iota
+	// FrameOriginStdlib denotes a frame in the standard library or runtime.
+	FrameOriginStdlib
+	// FrameOriginVendor denotes a frame in a third-party module.
+	FrameOriginVendor
+)
+
+// String implements fmt.Stringer.
+func (o FrameOrigin) String() string {
+	switch o {
+	case FrameOriginModule:
+		return "module"
+	case FrameOriginStdlib:
+		return "stdlib"
+	case FrameOriginVendor:
+		return "vendor"
+	default:
+		return "FrameOrigin(" + strconv.Itoa(int(o)) + ")"
+	}
+}
+
+// Frame is a single stack frame.
+type Frame struct {
+	// Function is the fully qualified function name, e.g. "github.com/ibrt/errors.Wrap".
+	Function string
+	// Package is the import path of the package containing the function, e.g. "github.com/ibrt/errors".
+	Package string
+	// File is the absolute path of the source file.
+	File string
+	// Line is the line number in the source file.
+	Line int
+	// PC is the program counter of the frame.
+	PC uintptr
+	// Origin classifies the frame based on its package.
+	Origin FrameOrigin
+}
+
+// Frames returns the stack trace stored in the error metadata as a slice of frames, if any.
+// It returns nil if no stack trace was set.
+func Frames(err error) []Frame {
+	if callers := GetCallers(err); callers != nil {
+		return CallersFrames(callers)
+	}
+	return nil
+}
+
+// CallersFrames converts the given callers to a slice of frames.
+func CallersFrames(callers []uintptr) []Frame {
+	if len(callers) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(callers)
+	result := make([]Frame, 0, len(callers))
+
+	for {
+		frame, more := frames.Next()
+		result = append(result, newFrame(frame))
+
+		if !more {
+			break
+		}
+	}
+
+	return result
+}
+
+// newFrame converts a runtime.Frame to a Frame.
+func newFrame(frame runtime.Frame) Frame {
+	pkg := framePackage(frame.Function)
+
+	return Frame{
+		Function: frame.Function,
+		Package:  pkg,
+		File:     frame.File,
+		Line:     frame.Line,
+		PC:       frame.PC,
+		Origin:   frameOrigin(pkg, frame.File),
+	}
+}
+
+// framePackage extracts the package import path from a fully qualified function name.
+func framePackage(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[lastSlash+1:], "."); dot >= 0 {
+		return function[:lastSlash+1+dot]
+	}
+	return function
+}
+
+// frameOrigin classifies a frame based on its package import path and file.
+func frameOrigin(pkg, file string) FrameOrigin {
+	pkg = strings.TrimSuffix(pkg, "_test")
+	modulePath := GetModulePath()
+
+	switch {
+	case modulePath != "" && (pkg == modulePath || strings.HasPrefix(pkg, modulePath+"/")):
+		return FrameOriginModule
+	case strings.Contains(file, "/vendor/"):
+		return FrameOriginVendor
+	case !strings.Contains(strings.SplitN(pkg, "/", 2)[0], "."):
+		return FrameOriginStdlib
+	default:
+		return FrameOriginVendor
+	}
+}
+
+// ShortFunction returns the function name qualified by the package name only, e.g. "errors.Wrap".
+func (f Frame) ShortFunction() string {
+	return filepath.Base(f.Function)
+}
+
+// ShortFile returns the base name of the source file, e.g. "error.go".
+func (f Frame) ShortFile() string {
+	return filepath.Base(f.File)
+}
+
+// RelativeFile returns the path of the source file relative to the module or GOPATH root, i.e. the package import path
+// joined with the base name of the source file, e.g. "github.com/ibrt/errors/error.go".
+func (f Frame) RelativeFile() string {
+	if f.Package == "" {
+		return f.ShortFile()
+	}
+	return path.Join(strings.TrimSuffix(f.Package, "_test"), f.ShortFile())
+}
+
+// String implements fmt.Stringer. It returns the same representation as FormatCallers, e.g.
+// "errors.Wrap (/path/to/error.go:12)".
+func (f Frame) String() string {
+	return fmt.Sprintf("%v (%v:%v)", f.ShortFunction(), f.File, f.Line)
+}
+
+// Format implements fmt.Formatter. It supports the following verbs:
+//
+//	%s    short source file name
+//	%+s   absolute source file path
+//	%#s   source file path relative to the module or GOPATH root
+//	%d    source line
+//	%n    short function name
+//	%+n   fully qualified function name
+//	%v    same as String
+//	%+v   fully qualified function name and absolute source file path
+func (f Frame) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		switch {
+		case s.Flag('+'):
+			io.WriteString(s, f.File)
+		case s.Flag('#'):
+			io.WriteString(s, f.RelativeFile())
+		default:
+			io.WriteString(s, f.ShortFile())
+		}
+	case 'd':
+		io.WriteString(s, strconv.Itoa(f.Line))
+	case 'n':
+		if s.Flag('+') {
+			io.WriteString(s, f.Function)
+		} else {
+			io.WriteString(s, f.ShortFunction())
+		}
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%v (%v:%v)", f.Function, f.File, f.Line)
+		} else {
+			io.WriteString(s, f.String())
+		}
+	default:
+		fmt.Fprintf(s, "%%!%c(errors.Frame=%v)", verb, f.String())
+	}
+}
@@ -0,0 +1,104 @@
+package errors_test
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleFrames() {
+	err := errors.Errorf("test error")
+	frame := errors.Frames(err)[0]
+
+	fmt.Println(frame.Package)
+	fmt.Println(frame.Origin)
+	fmt.Printf("%n\n", frame)
+	fmt.Printf("%s\n", frame)
+	fmt.Printf("%#s\n", frame)
+
+	// Output:
+	// github.com/ibrt/errors_test
+	// module
+	// errors_test.ExampleFrames
+	// frames_test.go
+	// github.com/ibrt/errors/frames_test.go
+}
+
+func TestFrames(t *testing.T) {
+	require.Nil(t, errors.Frames(fmt.Errorf("test error")))
+
+	err := errors.Errorf("test error")
+	frames := errors.Frames(err)
+	require.Equal(t, errors.FormatCallers(errors.GetCallers(err)), func() []string {
+		formatted := make([]string, 0, len(frames))
+		for _, frame := range frames {
+			formatted = append(formatted, frame.String())
+		}
+		return formatted
+	}())
+
+	require.Equal(t, "github.com/ibrt/errors_test.TestFrames", frames[0].Function)
+	require.Equal(t, "github.com/ibrt/errors_test", frames[0].Package)
+	require.True(t, strings.HasSuffix(frames[0].File, "/frames_test.go"))
+	require.NotZero(t, frames[0].Line)
+	require.NotZero(t, frames[0].PC)
+	require.Equal(t, errors.FrameOriginModule, frames[0].Origin)
+
+	require.Equal(t, "testing.tRunner", frames[1].ShortFunction())
+	require.Equal(t, "testing", frames[1].Package)
+	require.Equal(t, errors.FrameOriginStdlib, frames[1].Origin)
+}
+
+func TestSetModulePath(t *testing.T) {
+	modulePath := errors.GetModulePath()
+	defer errors.SetModulePath(modulePath)
+	require.Equal(t, "github.com/ibrt/errors", modulePath)
+
+	errors.SetModulePath("example.com/other")
+	require.Equal(t, "example.com/other", errors.GetModulePath())
+	require.Equal(t, errors.FrameOriginVendor, errors.Frames(errors.Errorf("test error"))[0].Origin)
+
+	errors.SetModulePath("github.com/ibrt")
+	require.Equal(t, errors.FrameOriginModule, errors.Frames(errors.Errorf("test error"))[0].Origin)
+}
+
+func TestCallersFrames(t *testing.T) {
+	require.Nil(t, errors.CallersFrames(nil))
+
+	callers := make([]uintptr, 1024)
+	frames := errors.CallersFrames(callers[:runtime.Callers(1, callers)])
+	require.Len(t, frames, 3)
+	require.Equal(t, "errors_test.TestCallersFrames", frames[0].ShortFunction())
+	require.Equal(t, "runtime.goexit", frames[2].ShortFunction())
+	require.Equal(t, "runtime", frames[2].Package)
+}
+
+func TestFrame_Format(t *testing.T) {
+	frame := errors.Frame{
+		Function: "github.com/ibrt/errors.(*wrappedError).Format",
+		Package:  "github.com/ibrt/errors",
+		File:     "/home/user/errors/format.go",
+		Line:     12,
+	}
+
+	require.Equal(t, "format.go", fmt.Sprintf("%s", frame))
+	require.Equal(t, "/home/user/errors/format.go", fmt.Sprintf("%+s", frame))
+	require.Equal(t, "github.com/ibrt/errors/format.go", fmt.Sprintf("%#s", frame))
+	require.Equal(t, "12", fmt.Sprintf("%d", frame))
+	require.Equal(t, "errors.(*wrappedError).Format", fmt.Sprintf("%n", frame))
+	require.Equal(t, "github.com/ibrt/errors.(*wrappedError).Format", fmt.Sprintf("%+n", frame))
+	require.Equal(t, "errors.(*wrappedError).Format (/home/user/errors/format.go:12)", fmt.Sprintf("%v", frame))
+	require.Equal(t, "github.com/ibrt/errors.(*wrappedError).Format (/home/user/errors/format.go:12)", fmt.Sprintf("%+v", frame))
+	require.Equal(t, "format.go", (errors.Frame{File: "/format.go"}).RelativeFile())
+}
+
+func TestFrameOrigin_String(t *testing.T) {
+	require.Equal(t, "module", errors.FrameOriginModule.String())
+	require.Equal(t, "stdlib", errors.FrameOriginStdlib.String())
+	require.Equal(t, "vendor", errors.FrameOriginVendor.String())
+	require.Equal(t, "FrameOrigin(3)", errors.FrameOrigin(3).String())
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// GroupOption describes an option for NewGroup.
+type GroupOption func(*groupOptions)
+
+type groupOptions struct {
+	collectAll bool
+}
+
+// GroupCollectAll returns a GroupOption that disables the cancellation of the group context on the first error, so that
+// all functions run to completion regardless of failures.
+func GroupCollectAll() GroupOption {
+	return func(o *groupOptions) {
+		o.collectAll = true
+	}
+}
+
+// Go calls fn in a new goroutine, and returns a channel that receives its result and is then closed. If fn panics, the
+// panic is recovered and converted to a wrapped error with MaybeWrapRecover, whose stack trace is the one of the
+// panicking goroutine.
+func Go(fn func() error) <-chan error {
+	ch := make(chan error, 1)
+
+	go func() {
+		defer close(ch)
+		ch <- callRecover(fn)
+	}()
+
+	return ch
+}
+
+// Group runs functions in separate goroutines, recovering their panics as in Go, and gathers all their failures into a
+// compound error (see Append). The zero value is ready to use: it has no context, and collects all failures.
+type Group struct {
+	wg            sync.WaitGroup
+	m             sync.Mutex
+	err           error
+	cancel        context.CancelCauseFunc
+	cancelOnError bool
+}
+
+// NewGroup creates a new Group, and a context derived from ctx. Unless GroupCollectAll is given, the context is
+// canceled as soon as a function returns an error or panics, with such error as cause. In any case, it is canceled when
+// Wait returns.
+func NewGroup(ctx context.Context, options ...GroupOption) (*Group, context.Context) {
+	opts := &groupOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	return &Group{
+		cancel:        cancel,
+		cancelOnError: !opts.collectAll,
+	}, ctx
+}
+
+// Go calls fn in a new goroutine. Its failure, if any, is added to the error returned by Wait.
+func (g *Group) Go(fn func() error) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		if err := callRecover(fn); err != nil {
+			g.m.Lock()
+			g.err = Append(g.err, err)
+			g.m.Unlock()
+
+			if g.cancelOnError {
+				g.cancel(err)
+			}
+		}
+	}()
+}
+
+// Wait waits for all functions to return, and then returns their failures, if any. Failures are gathered in completion
+// order: if more than one function failed, a compound error is returned.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+
+	if g.cancel != nil {
+		g.cancel(nil)
+	}
+
+	g.m.Lock()
+	defer g.m.Unlock()
+	return g.err
+}
+
+// callRecover calls fn, converting panics to wrapped errors.
+func callRecover(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = MaybeWrapRecover(r, Skip(1))
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,115 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func panicInGoroutine() error {
+	panic("test panic")
+}
+
+func ExampleGroup() {
+	g := &errors.Group{}
+
+	for i := 0; i < 3; i++ {
+		i := i
+		g.Go(func() error {
+			if i == 1 {
+				panic("test panic")
+			}
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	fmt.Println(err.Error())
+
+	// Output:
+	// test panic
+}
+
+func TestGo(t *testing.T) {
+	require.NoError(t, <-errors.Go(func() error { return nil }))
+	require.EqualError(t, <-errors.Go(func() error { return fmt.Errorf("test error") }), "test error")
+
+	ch := errors.Go(panicInGoroutine)
+	err := <-ch
+	require.EqualError(t, err, "test panic")
+	require.True(t, hasFormattedCaller(err, "errors_test.panicInGoroutine"))
+
+	_, ok := <-ch
+	require.False(t, ok)
+
+	err = <-errors.Go(func() error { panic(errors.Errorf("test error", errors.HTTPStatusConflict)) })
+	require.EqualError(t, err, "test error")
+	require.Equal(t, 409, errors.GetHTTPStatus(err))
+}
+
+func TestGroup(t *testing.T) {
+	g := &errors.Group{}
+	require.NoError(t, g.Wait())
+
+	for i := 0; i < 10; i++ {
+		i := i
+		g.Go(func() error {
+			if i%5 == 0 {
+				return fmt.Errorf("test error %v", i)
+			}
+			if i == 7 {
+				return panicInGoroutine()
+			}
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	errs := errors.Split(err)
+	require.Len(t, errs, 3)
+
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	require.ElementsMatch(t, []string{"test error 0", "test error 5", "test panic"}, messages)
+}
+
+func TestNewGroup(t *testing.T) {
+	g, ctx := errors.NewGroup(context.Background())
+	started := make(chan struct{})
+
+	g.Go(func() error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.Go(func() error {
+		<-started
+		return fmt.Errorf("test error")
+	})
+
+	err := g.Wait()
+	require.EqualError(t, context.Cause(ctx), "test error")
+	require.Len(t, errors.Split(err), 2)
+	require.True(t, errors.Equals(err, context.Canceled))
+
+	g, ctx = errors.NewGroup(context.Background(), errors.GroupCollectAll())
+	g.Go(func() error { return fmt.Errorf("test error") })
+	g.Go(func() error { return fmt.Errorf("test error") })
+	require.Len(t, errors.Split(g.Wait()), 2)
+	require.Equal(t, context.Canceled, context.Cause(ctx))
+}
+
+func hasFormattedCaller(err error, prefix string) bool {
+	for _, caller := range errors.GetFormattedCallers(err) {
+		if strings.HasPrefix(caller, prefix) {
+			return true
+		}
+	}
+	return false
+}
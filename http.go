@@ -1,10 +1,13 @@
 package errors
 
 import (
+	"context"
+	stderrors "errors"
 	"net/http"
-	"reflect"
 )
 
+var httpStatusKey = NewKey[int]("errors.HTTPStatus")
+
 // Shorthand HTTPStatus behaviors for 4xx and 5xx HTTP status codes registered with IANA.
 // See: https://www.iana.org/assignments/http-status-codes/http-status-codes.xhtml
 var (
@@ -50,16 +53,31 @@ var (
 
 // HTTPStatus returns a behavior that stores a HTTP status in the error metadata.
 func HTTPStatus(status int) Behavior {
-	return Metadata(reflect.ValueOf(HTTPStatus), status)
+	return httpStatusKey.With(status)
 }
 
-// GetHTTPStatus extracts a HTTP status from the error metadata, if any.
-// It returns 0 if no HTTP status was set.
+// GetHTTPStatus extracts a HTTP status from the error metadata, if any. If no HTTP status was set, but a canonical
+// status code was (see WithCode), the corresponding HTTP status is returned, unless it is not an error status (e.g. for
+// CodeOK). Otherwise errors caused by context.Canceled and context.DeadlineExceeded get 499 (client closed request) and
+// 504 respectively. It returns 0 in all other cases.
 func GetHTTPStatus(err error) int {
-	if status, ok := GetMetadata(err, reflect.ValueOf(HTTPStatus)).(int); ok {
+	if status, ok := httpStatusKey.Get(err); ok {
 		return status
 	}
-	return 0
+	if code, ok := codeKey.Get(err); ok {
+		if status := code.HTTPStatus(); status >= 400 {
+			return status
+		}
+	}
+
+	switch {
+	case stderrors.Is(err, context.Canceled):
+		return 499 // client closed request
+	case stderrors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return 0
+	}
 }
 
 // GetHTTPStatusOrDefault extracts a HTTP status from the error metadata, if any.
@@ -0,0 +1,303 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// JSONVersion is the version of the JSON document produced by EncodeJSON and accepted by DecodeJSON.
+const JSONVersion = 1
+
+var formattedCallersKey = NewKey[[]string]("errors.FormattedCallers")
+
+// jsonKey is a typed metadata key whose values can be decoded from JSON.
+type jsonKey interface {
+	String() string
+	decodeJSON(buf json.RawMessage) (interface{}, error)
+}
+
+// jsonKeys holds the typed keys of the built-in behaviors that are encoded as metadata, indexed by name, so that
+// DecodeJSON can restore them.
+var jsonKeys = newJSONKeys(
+	codeKey, errorCodeKey, severityKey, fieldKey, fingerprintKey, retryableKey, retryAfterKey, responseInfoKey,
+	problemTypeKey, problemTitleKey, problemInstanceKey, problemExtensionsKey,
+	RequestIDKey, TenantIDKey, UserIDKey, TraceIDKey)
+
+// newJSONKeys indexes the given keys by name.
+func newJSONKeys(keys ...jsonKey) map[string]jsonKey {
+	m := make(map[string]jsonKey, len(keys))
+	for _, key := range keys {
+		m[key.String()] = key
+	}
+	return m
+}
+
+// decodeJSON decodes a JSON value of the type of the key.
+func (k *Key[T]) decodeJSON(buf json.RawMessage) (interface{}, error) {
+	var value T
+	if err := json.Unmarshal(buf, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// jsonError is the JSON representation of a wrapped or compound error.
+type jsonError struct {
+	Version       int                        `json:"version,omitempty"`
+	Message       string                     `json:"message"`
+	Cause         string                     `json:"cause,omitempty"`
+	Prefix        string                     `json:"prefix,omitempty"`
+	HTTPStatus    int                        `json:"httpStatus,omitempty"`
+	PublicMessage string                     `json:"publicMessage,omitempty"`
+	Metadata      map[string]json.RawMessage `json:"metadata,omitempty"`
+	Callers       []string                   `json:"callers,omitempty"`
+	Errors        []*jsonError               `json:"errors,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *wrappedError) MarshalJSON() ([]byte, error) {
+	jErr := newJSONError(e)
+	jErr.Version = JSONVersion
+	return json.Marshal(jErr)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e wrappedErrors) MarshalJSON() ([]byte, error) {
+	jErr := newJSONErrors(e)
+	jErr.Version = JSONVersion
+	return json.Marshal(jErr)
+}
+
+// EncodeJSON encodes the given error as a versioned JSON document, which includes the message, prefix, HTTP status,
+// public message, formatted stack trace and metadata. Metadata keys are encoded using their human-readable
+// representation, suffixed by "#2", "#3", etc. if distinct keys share the same one. Metadata values that cannot be
+// encoded as JSON are replaced by their "%v" representation. Errors that are not wrapped are encoded using their
+// message only.
+func EncodeJSON(err error) ([]byte, error) {
+	if err == nil {
+		panic("nil error")
+	}
+
+	switch err := err.(type) {
+	case *wrappedError:
+		return err.MarshalJSON()
+	case wrappedErrors:
+		return err.MarshalJSON()
+	default:
+		return json.Marshal(&jsonError{
+			Version: JSONVersion,
+			Message: err.Error(),
+			Cause:   err.Error(),
+		})
+	}
+}
+
+// DecodeJSON decodes a JSON document produced by EncodeJSON, rebuilding a wrapped or compound error. The original
+// error is replaced by a plain error carrying the same message, and the stack trace is only available in its formatted
+// form via GetFormattedCallers. Metadata stored by built-in behaviors (e.g. WithCode, ErrorCode, WithSeverity, Field,
+// Retryable, RetryAfter, the problem behaviors and the context keys) is restored under the respective typed keys, so
+// that their getters keep working. Other metadata, including values that cannot be decoded as the type of the
+// respective typed key, is stored under string keys. The second returned value is non-nil if the document cannot be
+// decoded.
+func DecodeJSON(data []byte) (error, error) {
+	jErr := &jsonError{}
+
+	if err := json.Unmarshal(data, jErr); err != nil {
+		return nil, Wrap(err, Prefix("cannot decode error"))
+	}
+	if jErr.Version != JSONVersion {
+		return nil, Errorf("unsupported error document version: %v", jErr.Version)
+	}
+
+	if len(jErr.Errors) > 0 && jErr.Cause == "" {
+		wErrs, err := jErr.toWrappedErrors()
+		if err != nil {
+			return nil, err
+		}
+		return wErrs, nil
+	}
+
+	wErr, err := jErr.toWrappedError()
+	if err != nil {
+		return nil, err
+	}
+	return wErr, nil
+}
+
+// GetFormattedCallers returns a human-readable version of the stack trace stored in the error metadata, if any, after
+// applying the format time filters (see FilteredFrames). For errors rebuilt by DecodeJSON it returns the stack trace as
+// it was encoded. It returns nil if no stack trace was set.
+func GetFormattedCallers(err error) []string {
+	if frames := FilteredFrames(err); frames != nil {
+		formattedCallers := make([]string, 0, len(frames))
+		for _, frame := range frames {
+			formattedCallers = append(formattedCallers, frame.String())
+		}
+		return formattedCallers
+	}
+	callers, _ := formattedCallersKey.Get(err)
+	return callers
+}
+
+// newJSONError converts a wrapped error to its JSON representation.
+func newJSONError(e *wrappedError) *jsonError {
+	jErr := &jsonError{
+		Message:       e.Error(),
+		Cause:         e.err.Error(),
+		Prefix:        GetPrefix(e),
+		HTTPStatus:    GetHTTPStatus(e),
+		PublicMessage: GetPublicMessage(e),
+		Callers:       GetFormattedCallers(e),
+	}
+
+	if metadata := userMetadata(e); len(metadata) > 0 {
+		jErr.Metadata = marshalMetadata(metadata)
+	}
+
+	if inner := children(e); inner != nil {
+		jErr.Errors = newJSONErrors(inner).Errors
+	}
+
+	return jErr
+}
+
+// newJSONErrors converts a compound error to its JSON representation.
+func newJSONErrors(e wrappedErrors) *jsonError {
+	jErr := &jsonError{
+		Message: e.Error(),
+		Errors:  make([]*jsonError, 0, len(e)),
+	}
+
+	for _, wErr := range e {
+		jErr.Errors = append(jErr.Errors, newJSONError(wErr))
+	}
+
+	return jErr
+}
+
+// jsonMetadataEntry is a metadata entry encoded as JSON, used to resolve keys sharing the same human-readable name.
+type jsonMetadataEntry struct {
+	builtIn bool
+	keyType string
+	value   json.RawMessage
+}
+
+// marshalMetadata encodes the given metadata, keyed by the human-readable representation of its keys. Distinct keys
+// sharing the same representation are disambiguated by appending "#2", "#3", etc. to their name, in a deterministic
+// order: the built-in typed key with such name (if any) comes first, then keys are sorted by type and encoded value.
+func marshalMetadata(metadata map[interface{}]interface{}) map[string]json.RawMessage {
+	entries := make(map[string][]*jsonMetadataEntry, len(metadata))
+
+	for key, value := range metadata {
+		name := formatMetadataKey(key)
+		typedKey, ok := jsonKeys[name]
+		entries[name] = append(entries[name], &jsonMetadataEntry{
+			builtIn: ok && interface{}(typedKey) == key,
+			keyType: fmt.Sprintf("%T", key),
+			value:   marshalMetadataValue(value),
+		})
+	}
+
+	m := make(map[string]json.RawMessage, len(metadata))
+
+	for name, nameEntries := range entries {
+		sort.Slice(nameEntries, func(i, j int) bool {
+			if nameEntries[i].builtIn != nameEntries[j].builtIn {
+				return nameEntries[i].builtIn
+			}
+			if nameEntries[i].keyType != nameEntries[j].keyType {
+				return nameEntries[i].keyType < nameEntries[j].keyType
+			}
+			return bytes.Compare(nameEntries[i].value, nameEntries[j].value) < 0
+		})
+
+		for i, entry := range nameEntries {
+			if i == 0 {
+				m[name] = entry.value
+			} else {
+				m[fmt.Sprintf("%v#%v", name, i+1)] = entry.value
+			}
+		}
+	}
+
+	return m
+}
+
+// marshalMetadataValue encodes a metadata value, falling back to its "%v" representation if it cannot be encoded.
+func marshalMetadataValue(value interface{}) (buf json.RawMessage) {
+	defer func() {
+		if r := recover(); r != nil {
+			buf, _ = json.Marshal(fmt.Sprintf("%v", value))
+		}
+	}()
+
+	var err error
+	if buf, err = json.Marshal(value); err != nil {
+		buf, _ = json.Marshal(fmt.Sprintf("%v", value))
+	}
+	return buf
+}
+
+// toWrappedErrors rebuilds a compound error from the inner errors of its JSON representation.
+func (e *jsonError) toWrappedErrors() (wrappedErrors, error) {
+	wErrs := make(wrappedErrors, 0, len(e.Errors))
+	for i, jErr := range e.Errors {
+		if jErr == nil {
+			return nil, Errorf("cannot decode error: null inner error at index %v", i)
+		}
+		wErr, err := jErr.toWrappedError()
+		if err != nil {
+			return nil, err
+		}
+		wErrs = append(wErrs, wErr)
+	}
+	return wErrs, nil
+}
+
+// toWrappedError rebuilds a wrapped error or node from its JSON representation.
+func (e *jsonError) toWrappedError() (*wrappedError, error) {
+	wErr := &wrappedError{
+		err:      fmt.Errorf("%s", e.Cause),
+		metadata: make(map[interface{}]interface{}),
+	}
+
+	if len(e.Errors) > 0 {
+		inner, err := e.toWrappedErrors()
+		if err != nil {
+			return nil, err
+		}
+		wErr.err = inner
+	}
+
+	if e.Prefix != "" {
+		wErr.metadata[prefixKey] = e.Prefix
+	}
+	if e.HTTPStatus != 0 {
+		wErr.metadata[httpStatusKey] = e.HTTPStatus
+	}
+	if e.PublicMessage != "" {
+		wErr.metadata[publicMessageKey] = e.PublicMessage
+	}
+	if len(e.Callers) > 0 {
+		wErr.metadata[formattedCallersKey] = e.Callers
+	}
+
+	for key, buf := range e.Metadata {
+		if typedKey, ok := jsonKeys[key]; ok {
+			if value, err := typedKey.decodeJSON(buf); err == nil {
+				wErr.metadata[typedKey] = value
+				continue
+			}
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(buf, &value); err != nil {
+			return nil, Wrap(err, Prefix("cannot decode metadata %q", key))
+		}
+		wErr.metadata[key] = value
+	}
+
+	return wErr, nil
+}
@@ -0,0 +1,206 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleEncodeJSON() {
+	err := errors.Errorf("test error",
+		errors.Prefix("prefix"),
+		errors.HTTPStatus(http.StatusNotFound),
+		errors.PublicMessage("not found"))
+
+	buf, _ := errors.EncodeJSON(err)
+	decodedErr, _ := errors.DecodeJSON(buf)
+
+	fmt.Println(decodedErr.Error())
+	fmt.Println(errors.GetHTTPStatus(decodedErr))
+	fmt.Println(errors.GetPublicMessage(decodedErr))
+
+	// Output:
+	// prefix: test error
+	// 404
+	// not found
+}
+
+type testPanicMarshaler struct{}
+
+// MarshalJSON implements json.Marshaler.
+func (testPanicMarshaler) MarshalJSON() ([]byte, error) {
+	panic("marshal panic")
+}
+
+// String implements fmt.Stringer.
+func (testPanicMarshaler) String() string {
+	return "panic marshaler"
+}
+
+func TestEncodeJSON(t *testing.T) {
+	err := errors.Errorf("test error",
+		errors.Prefix("prefix"),
+		errors.HTTPStatus(http.StatusNotFound),
+		errors.PublicMessage("not found"),
+		errors.Metadata("key", "value"),
+		errors.Metadata("num", 1),
+		errors.Metadata("func", func() {}),
+		errors.Metadata("panic", testPanicMarshaler{}))
+
+	buf, encodeErr := errors.EncodeJSON(err)
+	require.NoError(t, encodeErr)
+
+	buf2, encodeErr := json.Marshal(err)
+	require.NoError(t, encodeErr)
+	require.JSONEq(t, string(buf), string(buf2))
+
+	m := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf, &m))
+	require.Equal(t, float64(errors.JSONVersion), m["version"])
+	require.Equal(t, "prefix: test error", m["message"])
+	require.Equal(t, "test error", m["cause"])
+	require.Equal(t, "prefix: ", m["prefix"])
+	require.Equal(t, float64(http.StatusNotFound), m["httpStatus"])
+	require.Equal(t, "not found", m["publicMessage"])
+	require.Equal(t, "value", m["metadata"].(map[string]interface{})["key"])
+	require.Equal(t, float64(1), m["metadata"].(map[string]interface{})["num"])
+	require.IsType(t, "", m["metadata"].(map[string]interface{})["func"])
+	require.Equal(t, "panic marshaler", m["metadata"].(map[string]interface{})["panic"])
+	require.Regexp(t, "^errors_test.TestEncodeJSON", m["callers"].([]interface{})[0])
+
+	buf, encodeErr = errors.EncodeJSON(fmt.Errorf("test error"))
+	require.NoError(t, encodeErr)
+	require.JSONEq(t, `{"version":1,"message":"test error","cause":"test error"}`, string(buf))
+
+	require.PanicsWithValue(t, "nil error", func() { _, _ = errors.EncodeJSON(nil) })
+}
+
+func TestDecodeJSON(t *testing.T) {
+	err := errors.Errorf("test error",
+		errors.Prefix("prefix"),
+		errors.HTTPStatus(http.StatusNotFound),
+		errors.PublicMessage("not found"),
+		errors.Metadata("key", "value"))
+
+	buf, encodeErr := errors.EncodeJSON(err)
+	require.NoError(t, encodeErr)
+
+	decodedErr, decodeErr := errors.DecodeJSON(buf)
+	require.NoError(t, decodeErr)
+	require.Equal(t, "prefix: test error", decodedErr.Error())
+	require.Equal(t, "prefix: ", errors.GetPrefix(decodedErr))
+	require.Equal(t, http.StatusNotFound, errors.GetHTTPStatus(decodedErr))
+	require.Equal(t, "not found", errors.GetPublicMessage(decodedErr))
+	require.Equal(t, "value", errors.GetMetadata(decodedErr, "key"))
+	require.Nil(t, errors.GetCallers(decodedErr))
+	require.Equal(t, errors.GetFormattedCallers(err), errors.GetFormattedCallers(decodedErr))
+
+	buf2, encodeErr := errors.EncodeJSON(decodedErr)
+	require.NoError(t, encodeErr)
+	require.JSONEq(t, string(buf), string(buf2))
+
+	_, decodeErr = errors.DecodeJSON([]byte(`{`))
+	require.Error(t, decodeErr)
+	_, decodeErr = errors.DecodeJSON([]byte(`{"version":2,"message":"test error"}`))
+	require.EqualError(t, decodeErr, "unsupported error document version: 2")
+	_, decodeErr = errors.DecodeJSON([]byte(`{"version":1,"errors":[null]}`))
+	require.EqualError(t, decodeErr, "cannot decode error: null inner error at index 0")
+	_, decodeErr = errors.DecodeJSON([]byte(`{"version":1,"cause":"test error","errors":[{"cause":"first error"},null]}`))
+	require.EqualError(t, decodeErr, "cannot decode error: null inner error at index 1")
+}
+
+func TestDecodeJSON_TypedKeys(t *testing.T) {
+	err := errors.Errorf("test error",
+		errCodeUserNotFound,
+		errors.WithCode(errors.CodeNotFound),
+		errors.WithSeverity(errors.SeverityInfo),
+		errors.Field("/id"),
+		errors.Retryable(true),
+		errors.RetryAfter(time.Second),
+		errors.ProblemType("https://example.com/problems/not-found"),
+		errors.ProblemExtension("id", "1"),
+		errors.RequestIDKey.With("request-id"),
+		errors.Metadata("key", "value"))
+
+	buf, encodeErr := errors.EncodeJSON(err)
+	require.NoError(t, encodeErr)
+
+	decodedErr, decodeErr := errors.DecodeJSON(buf)
+	require.NoError(t, decodeErr)
+	require.Equal(t, "user.not_found", errors.GetErrorCode(decodedErr))
+	require.Equal(t, errors.CodeNotFound, errors.GetCode(decodedErr))
+	require.Equal(t, errors.SeverityInfo, errors.GetSeverity(decodedErr))
+	require.Equal(t, "/id", errors.GetField(decodedErr))
+	require.True(t, errors.IsRetryable(decodedErr))
+	require.Equal(t, time.Second, errors.GetRetryAfter(decodedErr))
+	require.Equal(t, "https://example.com/problems/not-found", errors.GetProblemType(decodedErr))
+	require.Equal(t, map[string]interface{}{"id": "1"}, errors.GetProblemExtensions(decodedErr))
+	require.Equal(t, "request-id", errors.GetRequestID(decodedErr))
+	require.Equal(t, "value", errors.GetMetadata(decodedErr, "key"))
+
+	buf2, encodeErr := errors.EncodeJSON(decodedErr)
+	require.NoError(t, encodeErr)
+	require.JSONEq(t, string(buf), string(buf2))
+
+	decodedErr, decodeErr = errors.DecodeJSON([]byte(`{"version":1,"cause":"test error","metadata":{"errors.Severity":"Unknown"}}`))
+	require.NoError(t, decodeErr)
+	require.Equal(t, "Unknown", errors.GetMetadata(decodedErr, "errors.Severity"))
+
+	err = errors.Errorf("test error", errors.ProblemExtension("x", math.NaN()), errors.Metadata("errors.Code", "foo"))
+	buf, encodeErr = errors.EncodeJSON(err)
+	require.NoError(t, encodeErr)
+	decodedErr, decodeErr = errors.DecodeJSON(buf)
+	require.NoError(t, decodeErr)
+	require.Equal(t, "map[x:NaN]", errors.GetMetadata(decodedErr, "errors.ProblemExtensions"))
+	require.Equal(t, "foo", errors.GetMetadata(decodedErr, "errors.Code"))
+	require.Equal(t, errors.CodeUnknown, errors.GetCode(decodedErr))
+}
+
+func TestEncodeJSON_DuplicateKeys(t *testing.T) {
+	key1 := errors.NewKey[int]("k")
+	key2 := errors.NewKey[int]("k")
+	err := errors.Errorf("test error", key1.With(2), key2.With(1), errors.Metadata("k", "value"))
+
+	for i := 0; i < 10; i++ {
+		buf, encodeErr := errors.EncodeJSON(err)
+		require.NoError(t, encodeErr)
+
+		m := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(buf, &m))
+		require.Equal(t, map[string]interface{}{
+			"k":   float64(1),
+			"k#2": float64(2),
+			"k#3": "value",
+		}, m["metadata"])
+	}
+
+	err = errors.Errorf("test error", errors.WithCode(errors.CodeNotFound), errors.Metadata("errors.Code", 1))
+	buf, encodeErr := errors.EncodeJSON(err)
+	require.NoError(t, encodeErr)
+	decodedErr, decodeErr := errors.DecodeJSON(buf)
+	require.NoError(t, decodeErr)
+	require.Equal(t, errors.CodeNotFound, errors.GetCode(decodedErr))
+	require.Equal(t, float64(1), errors.GetMetadata(decodedErr, "errors.Code#2"))
+}
+
+func TestDecodeJSON_Compound(t *testing.T) {
+	err := errors.Append(
+		errors.Errorf("first error", errors.HTTPStatus(http.StatusNotFound)),
+		errors.Errorf("second error", errors.PublicMessage("public message")))
+
+	buf, encodeErr := errors.EncodeJSON(err)
+	require.NoError(t, encodeErr)
+
+	decodedErr, decodeErr := errors.DecodeJSON(buf)
+	require.NoError(t, decodeErr)
+	require.Equal(t, "multiple errors: first error · second error", decodedErr.Error())
+	require.Len(t, errors.Split(decodedErr), 2)
+	require.Equal(t, http.StatusNotFound, errors.GetHTTPStatus(decodedErr))
+	require.Equal(t, "public message", errors.GetPublicMessage(decodedErr))
+}
@@ -0,0 +1,45 @@
+package errors
+
+// Key is a typed metadata key. Each key created by NewKey has a unique identity, so keys defined in different packages
+// never collide, even if they share the same name and type. It provides a type-safe alternative to Metadata and
+// GetMetadata for implementing custom behaviors.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey creates a new typed metadata key. The name is only used for display purposes, e.g. when formatting errors.
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// String implements fmt.Stringer.
+func (k *Key[T]) String() string {
+	return k.name
+}
+
+// With returns a Behavior that stores the given value under this key in the error metadata.
+func (k *Key[T]) With(value T) Behavior {
+	return Metadata(k, value)
+}
+
+// Get extracts the value stored under this key from the error metadata. It returns false if no value was set. If err is
+// a compound error, the key is searched starting from the last inner error, and the first match (if any) is returned.
+func (k *Key[T]) Get(err error) (T, bool) {
+	if value, ok := lookupMetadata(err, k); ok {
+		if value, ok := value.(T); ok {
+			return value, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
+
+// GetOrDefault extracts the value stored under this key from the error metadata.
+// It returns the given default value if no value was set.
+func (k *Key[T]) GetOrDefault(err error, defaultValue T) T {
+	if value, ok := k.Get(err); ok {
+		return value
+	}
+	return defaultValue
+}
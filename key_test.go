@@ -0,0 +1,77 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var userIDKey = errors.NewKey[int64]("userID")
+
+func ExampleNewKey() {
+	// var userIDKey = errors.NewKey[int64]("userID")
+
+	doSomething := func() error {
+		return errors.Errorf("test error", userIDKey.With(1234))
+	}
+
+	if err := doSomething(); err != nil {
+		fmt.Println(userIDKey.Get(err))
+		fmt.Println(userIDKey.GetOrDefault(errors.Errorf("other error"), -1))
+	}
+
+	// Output:
+	// 1234 true
+	// -1
+}
+
+func TestKey(t *testing.T) {
+	err := errors.Errorf("test error")
+	value, ok := userIDKey.Get(err)
+	require.False(t, ok)
+	require.Equal(t, int64(0), value)
+	require.Equal(t, int64(-1), userIDKey.GetOrDefault(err, -1))
+
+	err = errors.Wrap(err, userIDKey.With(0))
+	value, ok = userIDKey.Get(err)
+	require.True(t, ok)
+	require.Equal(t, int64(0), value)
+	require.Equal(t, int64(0), errors.GetMetadata(err, userIDKey))
+
+	err = errors.Wrap(err, userIDKey.With(1))
+	require.Equal(t, int64(1), userIDKey.GetOrDefault(err, -1))
+
+	_, ok = userIDKey.Get(fmt.Errorf("test error"))
+	require.False(t, ok)
+}
+
+func TestKey_Identity(t *testing.T) {
+	otherKey := errors.NewKey[int64]("userID")
+	err := errors.Errorf("test error", userIDKey.With(1), otherKey.With(2))
+	require.Equal(t, int64(1), userIDKey.GetOrDefault(err, -1))
+	require.Equal(t, int64(2), otherKey.GetOrDefault(err, -1))
+	require.Equal(t, "userID", otherKey.String())
+
+	intKey := errors.NewKey[int]("int")
+	err = errors.Errorf("test error", errors.Metadata(intKey, int64(1)))
+	_, ok := intKey.Get(err)
+	require.False(t, ok)
+}
+
+func TestKey_Compound(t *testing.T) {
+	err := errors.Append(
+		errors.Errorf("first error", userIDKey.With(1)),
+		errors.Errorf("second error"))
+	require.Equal(t, int64(1), userIDKey.GetOrDefault(err, -1))
+
+	err = errors.Append(err, errors.Errorf("third error", userIDKey.With(3)))
+	require.Equal(t, int64(3), userIDKey.GetOrDefault(err, -1))
+}
+
+func TestKey_Format(t *testing.T) {
+	err := errors.Errorf("test error", userIDKey.With(1234))
+	require.True(t, strings.Contains(fmt.Sprintf("%+v", err), "\n    userID: 1234\n"))
+}
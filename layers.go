@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"strings"
+)
+
+// layerCallersDepth is the depth of the stack trace captured to record the call site of a layer: a single frame, plus
+// enough slack for Skip to be applied. It is trimmed to a single frame by finalizeCallers.
+const layerCallersDepth = 1 + callersSlack
+
+var layerCallersKey = NewKey[[]uintptr]("errors.LayerCallers")
+
+// Layer describes a single Wrap call on an error. The first layer is created when an error is first wrapped, and a new
+// one is added each time a wrapped error is re-wrapped.
+type Layer struct {
+	// PC is the program counter of the call site of the layer, or 0 if unknown.
+	PC uintptr
+	// Prefix is the prefix added in the layer, if any.
+	Prefix string
+	// Metadata contains the metadata stored in the layer, excluding the prefix and stack trace related keys.
+	Metadata map[interface{}]interface{}
+}
+
+// Caller returns a human-readable version of the call site of the layer, as in FormatCallers.
+// It returns "" if the call site is unknown.
+func (l *Layer) Caller() string {
+	if frame, ok := l.Frame(); ok {
+		return frame.String()
+	}
+	return ""
+}
+
+// Frame returns the call site of the layer. It returns false if the call site is unknown.
+func (l *Layer) Frame() (Frame, bool) {
+	if l.PC == 0 {
+		return Frame{}, false
+	}
+	return CallersFrames([]uintptr{l.PC})[0], true
+}
+
+// Layers returns the layers of the given wrapped error, from the oldest to the newest. Metadata lookups resolve keys
+// starting from the newest layer. If err is a compound error, the layers of the last inner error are returned. It
+// returns nil if the given error is not wrapped.
+func Layers(err error) []*Layer {
+	var wErr *wrappedError
+
+	switch err := err.(type) {
+	case *wrappedError:
+		wErr = err
+	case wrappedErrors:
+		wErr = err[len(err)-1]
+	default:
+		return nil
+	}
+
+	var layers []*Layer
+
+	for ; wErr != nil; wErr = wErr.parent {
+		layer := &Layer{
+			Metadata: make(map[interface{}]interface{}),
+		}
+
+		for key, value := range wErr.metadata {
+			if key != prefixKey && !isInternalKey(key) {
+				layer.Metadata[key] = value
+			}
+		}
+
+		if callers, ok := wErr.metadata[layerCallersKey].([]uintptr); ok && len(callers) > 0 {
+			layer.PC = callers[0]
+		} else if callers, ok := wErr.metadata[callersKey].([]uintptr); ok && len(callers) > 0 {
+			layer.PC = callers[0]
+		}
+
+		if prefix, ok := wErr.metadata[prefixKey].(string); ok {
+			layer.Prefix = prefix
+			if wErr.parent != nil {
+				layer.Prefix = strings.TrimSuffix(prefix, GetPrefix(wErr.parent))
+			}
+		}
+
+		layers = append([]*Layer{layer}, layers...)
+	}
+
+	return layers
+}
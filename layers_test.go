@@ -0,0 +1,73 @@
+package errors_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func readConfig() error {
+	return errors.Wrap(io.EOF, errors.Prefix("read failed"))
+}
+
+func loadConfig() error {
+	return errors.Wrap(readConfig(), errors.Prefix("load failed"), errors.HTTPStatusInternalServerError)
+}
+
+func ExampleLayers() {
+	for _, layer := range errors.Layers(loadConfig()) {
+		fmt.Printf("%v %q\n", strings.Fields(layer.Caller())[0], layer.Prefix)
+	}
+
+	// Output:
+	// errors_test.readConfig "read failed: "
+	// errors_test.loadConfig "load failed: "
+}
+
+func TestLayers(t *testing.T) {
+	err := loadConfig()
+	require.Equal(t, "load failed: read failed: EOF", err.Error())
+	require.Equal(t, http.StatusInternalServerError, errors.GetHTTPStatus(err))
+	require.True(t, strings.HasPrefix(errors.FormatCallers(errors.GetCallers(err))[0], "errors_test.readConfig"))
+
+	err = errors.Wrap(err, errors.Metadata("key", "value"))
+	layers := errors.Layers(err)
+	require.Len(t, layers, 3)
+
+	require.True(t, strings.HasPrefix(layers[0].Caller(), "errors_test.readConfig"))
+	require.Equal(t, "read failed: ", layers[0].Prefix)
+	require.Empty(t, layers[0].Metadata)
+
+	require.True(t, strings.HasPrefix(layers[1].Caller(), "errors_test.loadConfig"))
+	require.Equal(t, "load failed: ", layers[1].Prefix)
+	require.Len(t, layers[1].Metadata, 1)
+
+	require.True(t, strings.HasPrefix(layers[2].Caller(), "errors_test.TestLayers"))
+	require.Equal(t, "", layers[2].Prefix)
+	require.Equal(t, "value", layers[2].Metadata["key"])
+
+	require.Nil(t, errors.Layers(fmt.Errorf("test error")))
+	require.Equal(t, "", (&errors.Layer{}).Caller())
+}
+
+func TestLayers_Compound(t *testing.T) {
+	err := errors.Append(errors.Errorf("first error"), loadConfig())
+	err = errors.Wrap(err, errors.Prefix("prefix"))
+	layers := errors.Layers(err)
+	require.Len(t, layers, 3)
+	require.Equal(t, "prefix: ", layers[2].Prefix)
+	require.True(t, strings.HasPrefix(layers[2].Caller(), "errors_test.TestLayers_Compound"))
+}
+
+func TestLayers_Format(t *testing.T) {
+	formatted := fmt.Sprintf("%+v", errors.Wrap(loadConfig(), errors.Metadata("key", "value")))
+	require.Contains(t, formatted, "\n  layers:\n    [1] errors_test.readConfig")
+	require.Contains(t, formatted, "\n      prefix: read failed\n    [2] errors_test.loadConfig")
+	require.Contains(t, formatted, "\n    [3] errors_test.TestLayers_Format")
+	require.True(t, strings.HasSuffix(formatted, "\n      key: value"))
+}
@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+// HandlerFunc is like http.HandlerFunc, but returns an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ErrorHook is invoked by the middleware returned by Handler with the full wrapped error, e.g. for logging.
+type ErrorHook func(r *http.Request, err error)
+
+// HandlerOption customizes the middleware returned by Handler and HandleFunc.
+type HandlerOption func(*handlerOptions)
+
+type handlerOptions struct {
+	hooks          []ErrorHook
+	problemOptions []ProblemOption
+}
+
+// HandlerHook returns a HandlerOption that registers a hook, invoked with each error returned or recovered.
+func HandlerHook(hook ErrorHook) HandlerOption {
+	return func(o *handlerOptions) {
+		o.hooks = append(o.hooks, hook)
+	}
+}
+
+// HandlerProblemOptions returns a HandlerOption that customizes the Problem Details response written on error.
+func HandlerProblemOptions(options ...ProblemOption) HandlerOption {
+	return func(o *handlerOptions) {
+		o.problemOptions = append(o.problemOptions, options...)
+	}
+}
+
+// Handler returns a middleware that recovers panics in next, converting them to wrapped errors with MaybeWrapRecover,
+// so that the stack trace of the panic is preserved. Recovered errors are passed to the registered hooks and mapped to
+// a Problem Details response using WriteProblem. If next already wrote a response header, it is not written again:
+// after a panic, the hooks are invoked and the middleware panics with http.ErrAbortHandler, so that the server aborts
+// the partial response instead of completing it. Panics with http.ErrAbortHandler are propagated as well.
+func Handler(next http.Handler, options ...HandlerOption) http.Handler {
+	return HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		next.ServeHTTP(w, r)
+		return nil
+	}, options...)
+}
+
+// HandleFunc is like Handler, but accepts a HandlerFunc. Errors returned by fn are treated like recovered panics.
+func HandleFunc(fn HandlerFunc, options ...HandlerOption) http.Handler {
+	opts := &handlerOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tw := &trackingResponseWriter{ResponseWriter: w}
+		panicked := false
+
+		err := func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					if r == http.ErrAbortHandler {
+						panic(r)
+					}
+					panicked = true
+					err = MaybeWrapRecover(r)
+				}
+			}()
+			return MaybeWrap(fn(tw, r))
+		}()
+
+		if err == nil {
+			return
+		}
+
+		for _, hook := range opts.hooks {
+			hook(r, err)
+		}
+
+		if !tw.wroteHeader {
+			WriteProblem(w, r, err, opts.problemOptions...)
+		} else if panicked {
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
+// trackingResponseWriter is a http.ResponseWriter that tracks whether the response header was written.
+type trackingResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *trackingResponseWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter.
+func (w *trackingResponseWriter) Write(buf []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(buf)
+}
+
+// Flush implements http.Flusher.
+func (w *trackingResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, forwarding to the underlying http.ResponseWriter. It returns http.ErrNotSupported if
+// the latter does not implement http.Hijacker. After a successful hijack, no Problem Details response is written.
+func (w *trackingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the underlying http.ResponseWriter, for use with http.ResponseController.
+func (w *trackingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
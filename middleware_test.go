@@ -0,0 +1,164 @@
+package errors_test
+
+import (
+	"bufio"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleHandleFunc() {
+	h := errors.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.Errorf("user not found", errors.HTTPStatusNotFound, errors.PublicMessage("not found"))
+	}, errors.HandlerHook(func(r *http.Request, err error) {
+		fmt.Println("error:", err.Error())
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	fmt.Println(w.Code)
+	fmt.Println(w.Body.String())
+
+	// Output:
+	// error: user not found
+	// 404
+	// {"detail":"not found","instance":"/users/1","status":404,"title":"Not Found","type":"about:blank"}
+}
+
+func TestHandler(t *testing.T) {
+	var hookErr error
+
+	h := errors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("test panic")
+	}), errors.HandlerHook(func(r *http.Request, err error) {
+		hookErr = err
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, errors.ProblemContentType, w.Header().Get("Content-Type"))
+	require.NotContains(t, w.Body.String(), "test panic")
+	require.EqualError(t, hookErr, "test panic")
+	require.Contains(t, fmt.Sprintf("%+v", hookErr), "errors_test.TestHandler.func1")
+}
+
+func TestHandler_Success(t *testing.T) {
+	h := errors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}), errors.HandlerHook(func(r *http.Request, err error) {
+		require.Fail(t, "unexpected hook invocation")
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestHandler_Abort(t *testing.T) {
+	h := errors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func TestHandleFunc(t *testing.T) {
+	var hookErr error
+
+	h := errors.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("test error")
+	}, errors.HandlerHook(func(r *http.Request, err error) {
+		hookErr = err
+	}), errors.HandlerProblemOptions(errors.ProblemDebug(true)))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Contains(t, w.Body.String(), `"error":"test error"`)
+	require.EqualError(t, hookErr, "test error")
+	require.NotNil(t, errors.GetCallers(hookErr))
+}
+
+func TestHandleFunc_HeaderWritten(t *testing.T) {
+	var hookErr error
+
+	h := errors.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusAccepted)
+		return errors.Errorf("test error", errors.HTTPStatusBadRequest)
+	}, errors.HandlerHook(func(r *http.Request, err error) {
+		hookErr = err
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Empty(t, w.Body.String())
+	require.EqualError(t, hookErr, "test error")
+}
+
+func TestHandler_PanicAfterHeaderWritten(t *testing.T) {
+	var hookErr error
+
+	h := errors.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		panic("test panic")
+	}), errors.HandlerHook(func(r *http.Request, err error) {
+		hookErr = err
+	}))
+
+	w := httptest.NewRecorder()
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	require.EqualError(t, hookErr, "test panic")
+	require.Equal(t, "partial", w.Body.String())
+}
+
+type testHijacker struct {
+	*httptest.ResponseRecorder
+	conn net.Conn
+}
+
+// Hijack implements http.Hijacker.
+func (w *testHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.conn, bufio.NewReadWriter(bufio.NewReader(w.conn), bufio.NewWriter(w.conn)), nil
+}
+
+func TestHandler_Hijack(t *testing.T) {
+	conn, other := net.Pipe()
+	defer errors.IgnoreClose(conn)
+	defer errors.IgnoreClose(other)
+
+	var hookErr error
+
+	h := errors.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		hijacked, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		require.Equal(t, conn, hijacked)
+		return errors.Errorf("test error")
+	}, errors.HandlerHook(func(r *http.Request, err error) {
+		hookErr = err
+	}))
+
+	w := &testHijacker{ResponseRecorder: httptest.NewRecorder(), conn: conn}
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.EqualError(t, hookErr, "test error")
+	require.Empty(t, w.Body.String())
+
+	h = errors.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		_, _, err := w.(http.Hijacker).Hijack()
+		require.True(t, stderrors.Is(err, http.ErrNotSupported))
+		return nil
+	})
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,317 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type of Problem Details documents, as defined by RFC 9457.
+const ProblemContentType = "application/problem+json"
+
+var (
+	problemTypeKey       = NewKey[string]("errors.ProblemType")
+	problemTitleKey      = NewKey[string]("errors.ProblemTitle")
+	problemInstanceKey   = NewKey[string]("errors.ProblemInstance")
+	problemExtensionsKey = NewKey[map[string]interface{}]("errors.ProblemExtensions")
+)
+
+// Problem is a Problem Details document, as defined by RFC 9457 (formerly RFC 7807).
+// See: https://www.rfc-editor.org/rfc/rfc9457
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// MarshalJSON implements json.Marshaler. Extension members are serialized alongside the standard members, which take
+// precedence in case of conflicts.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+
+	m["type"] = p.Type
+	m["title"] = p.Title
+	m["status"] = p.Status
+	m["detail"] = p.Detail
+
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	} else {
+		delete(m, "instance")
+	}
+
+	return json.Marshal(m)
+}
+
+// nonStandardStatusTexts holds the text of commonly used HTTP statuses not registered with IANA, which are unknown to
+// http.StatusText.
+var nonStandardStatusTexts = map[int]string{
+	499: "Client Closed Request",
+}
+
+// ProblemOption customizes the Problem Details document built by NewProblem and WriteProblem.
+type ProblemOption func(*problemOptions)
+
+type problemOptions struct {
+	debug          bool
+	defaultStatus  int
+	defaultMessage string
+}
+
+// ProblemDebug returns a ProblemOption that includes the internal error message and stack trace in a "debug" extension
+// member. It must not be enabled in production, as it leaks internal details to clients.
+func ProblemDebug(debug bool) ProblemOption {
+	return func(o *problemOptions) {
+		o.debug = debug
+	}
+}
+
+// ProblemDefaultStatus returns a ProblemOption that sets the status used for errors without a 4xx or 5xx HTTP status.
+// It defaults to http.StatusInternalServerError, which is also used if the given status is not a 4xx or 5xx status.
+func ProblemDefaultStatus(status int) ProblemOption {
+	return func(o *problemOptions) {
+		o.defaultStatus = status
+	}
+}
+
+// ProblemDefaultMessage returns a ProblemOption that sets the detail used for errors without a public message.
+// It defaults to the status text of the HTTP status.
+func ProblemDefaultMessage(message string) ProblemOption {
+	return func(o *problemOptions) {
+		o.defaultMessage = message
+	}
+}
+
+// ProblemType returns a behavior that stores a Problem Details type URI in the error metadata.
+func ProblemType(uri string) Behavior {
+	return problemTypeKey.With(uri)
+}
+
+// GetProblemType extracts a Problem Details type URI from the error metadata, if any.
+// It returns "" if no type was set.
+func GetProblemType(err error) string {
+	return problemTypeKey.GetOrDefault(err, "")
+}
+
+// ProblemTitle returns a behavior that stores a Problem Details title in the error metadata.
+func ProblemTitle(title string) Behavior {
+	return problemTitleKey.With(title)
+}
+
+// GetProblemTitle extracts a Problem Details title from the error metadata, if any.
+// It returns "" if no title was set.
+func GetProblemTitle(err error) string {
+	return problemTitleKey.GetOrDefault(err, "")
+}
+
+// ProblemInstance returns a behavior that stores a Problem Details instance URI in the error metadata.
+func ProblemInstance(uri string) Behavior {
+	return problemInstanceKey.With(uri)
+}
+
+// GetProblemInstance extracts a Problem Details instance URI from the error metadata, if any.
+// It returns "" if no instance was set.
+func GetProblemInstance(err error) string {
+	return problemInstanceKey.GetOrDefault(err, "")
+}
+
+// ProblemExtension returns a behavior that stores a Problem Details extension member in the error metadata.
+// Multiple extension members can be stored by applying the behavior multiple times.
+func ProblemExtension(key string, value interface{}) Behavior {
+	return func(doubleWrap bool, err error) {
+		extensions := map[string]interface{}{key: value}
+		for k, v := range GetProblemExtensions(err) {
+			if k != key {
+				extensions[k] = v
+			}
+		}
+		problemExtensionsKey.With(extensions)(doubleWrap, err)
+	}
+}
+
+// GetProblemExtensions extracts the Problem Details extension members from the error metadata, if any.
+// It returns nil if no extension members were set.
+func GetProblemExtensions(err error) map[string]interface{} {
+	extensions, _ := problemExtensionsKey.Get(err)
+	return extensions
+}
+
+// NewProblem builds a Problem Details document from the given error. The status is extracted using GetHTTPStatus,
+// falling back to the default status (see ProblemDefaultStatus) if it is not a 4xx or 5xx status. The detail is
+// extracted using GetPublicMessageOrDefault, and type, title, instance and extension members from the respective
+// behaviors. The application error code (see ErrorCode) and the field (see Field), if any, are stored in "code" and
+// "pointer" extension members. The internal error message is never included unless ProblemDebug is enabled. If err is
+// a compound error or a node (see Nest), each inner error is also described in an "errors" extension member,
+// recursively. For compound errors, the top level status is the maximum status of the inner errors, and all other
+// members are set to their defaults, as the metadata of each inner error is specific to it.
+func NewProblem(r *http.Request, err error, options ...ProblemOption) *Problem {
+	if err == nil {
+		panic("nil error")
+	}
+
+	opts := &problemOptions{
+		defaultStatus: http.StatusInternalServerError,
+	}
+	for _, option := range options {
+		option(opts)
+	}
+
+	p := newProblem(err, opts)
+
+	if p.Instance == "" && r != nil && r.URL != nil {
+		p.Instance = r.URL.RequestURI()
+	}
+
+	if inner := children(err); inner != nil {
+		p.Extensions["errors"] = newProblems(inner, opts)
+	}
+
+	if opts.debug {
+		p.Extensions["debug"] = map[string]interface{}{
+			"error":   err.Error(),
+			"callers": GetFormattedCallers(err),
+		}
+	}
+
+	return p
+}
+
+// WriteProblem writes a Problem Details response built by NewProblem from the given error.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error, options ...ProblemOption) {
+	p := NewProblem(r, err, options...)
+
+	buf, marshalErr := json.Marshal(p)
+	if marshalErr != nil {
+		p = &Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError,
+			Detail: http.StatusText(http.StatusInternalServerError),
+		}
+		buf, _ = json.Marshal(p)
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_, _ = w.Write(buf)
+}
+
+// newProblems builds a Problem Details document for each of the given inner errors, recursing into nodes.
+func newProblems(wErrs wrappedErrors, opts *problemOptions) []*Problem {
+	problems := make([]*Problem, 0, len(wErrs))
+
+	for _, wErr := range wErrs {
+		p := newProblem(wErr, opts)
+		if inner := children(wErr); inner != nil {
+			p.Extensions["errors"] = newProblems(inner, opts)
+		}
+		problems = append(problems, p)
+	}
+
+	return problems
+}
+
+// newProblem builds a Problem Details document from the given error, without any compound or debug information.
+func newProblem(err error, opts *problemOptions) *Problem {
+	if wErrs, ok := err.(wrappedErrors); ok {
+		return newCompoundProblem(wErrs, opts)
+	}
+
+	status := problemStatus(GetHTTPStatus(err), opts)
+
+	p := &Problem{
+		Type:       GetProblemType(err),
+		Title:      GetProblemTitle(err),
+		Status:     status,
+		Detail:     GetPublicMessageOrDefault(err, problemDefaultMessage(status, opts)),
+		Instance:   GetProblemInstance(err),
+		Extensions: make(map[string]interface{}),
+	}
+
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+	if p.Title == "" {
+		p.Title = statusText(status)
+	}
+	if code := GetErrorCode(err); code != "" {
+		p.Extensions["code"] = code
+	}
+	if field := GetField(err); field != "" {
+		p.Extensions["pointer"] = field
+	}
+	for k, v := range GetProblemExtensions(err) {
+		p.Extensions[k] = v
+	}
+
+	return p
+}
+
+// newCompoundProblem builds the top level Problem Details document for a compound error. The metadata of a compound
+// error is the one of its inner errors, which does not describe the document as a whole: the status is the maximum
+// status of the inner errors, and all other members are set to their defaults.
+func newCompoundProblem(e wrappedErrors, opts *problemOptions) *Problem {
+	status := 0
+	for _, wErr := range e {
+		if s := GetHTTPStatus(wErr); s >= 400 && s <= 599 && s > status {
+			status = s
+		}
+	}
+	status = problemStatus(status, opts)
+
+	return &Problem{
+		Type:       "about:blank",
+		Title:      statusText(status),
+		Status:     status,
+		Detail:     problemDefaultMessage(status, opts),
+		Extensions: make(map[string]interface{}),
+	}
+}
+
+// problemDefaultMessage returns the default message (see ProblemDefaultMessage), or the text of the given status.
+func problemDefaultMessage(status int, opts *problemOptions) string {
+	if opts.defaultMessage != "" {
+		return opts.defaultMessage
+	}
+	return statusText(status)
+}
+
+// problemStatus returns the given status if it is a 4xx or 5xx status, or the default one otherwise. If the default
+// status is not a 4xx or 5xx status either, http.StatusInternalServerError is returned.
+func problemStatus(status int, opts *problemOptions) int {
+	switch {
+	case status >= 400 && status <= 599:
+		return status
+	case opts.defaultStatus >= 400 && opts.defaultStatus <= 599:
+		return opts.defaultStatus
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// statusText returns a text for the given HTTP status, as http.StatusText, falling back to nonStandardStatusTexts and
+// to a generic text based on the status class.
+func statusText(status int) string {
+	if text := http.StatusText(status); text != "" {
+		return text
+	}
+	if text, ok := nonStandardStatusTexts[status]; ok {
+		return text
+	}
+
+	switch {
+	case status >= 400 && status <= 499:
+		return "Client Error"
+	case status >= 500 && status <= 599:
+		return "Server Error"
+	default:
+		return "Error"
+	}
+}
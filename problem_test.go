@@ -0,0 +1,165 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleWriteProblem() {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+
+	errors.WriteProblem(w, r, errors.Errorf("user 1 not found in table users",
+		errors.HTTPStatusNotFound,
+		errors.PublicMessage("user not found")))
+
+	fmt.Println(w.Code)
+	fmt.Println(w.Header().Get("Content-Type"))
+	fmt.Println(w.Body.String())
+
+	// Output:
+	// 404
+	// application/problem+json
+	// {"detail":"user not found","instance":"/users/1","status":404,"title":"Not Found","type":"about:blank"}
+}
+
+func TestWriteProblem(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+
+	errors.WriteProblem(w, r, errors.Errorf("internal error",
+		errors.HTTPStatusConflict,
+		errors.PublicMessage("user already exists"),
+		errors.ProblemType("https://example.com/problems/conflict"),
+		errors.ProblemTitle("Conflict"),
+		errors.ProblemInstance("/users/1#conflict"),
+		errors.ProblemExtension("userId", 1),
+		errors.ProblemExtension("status", "ignored")))
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, errors.ProblemContentType, w.Header().Get("Content-Type"))
+	require.JSONEq(t, `{
+		"type": "https://example.com/problems/conflict",
+		"title": "Conflict",
+		"status": 409,
+		"detail": "user already exists",
+		"instance": "/users/1#conflict",
+		"userId": 1
+	}`, w.Body.String())
+	require.NotContains(t, w.Body.String(), "internal error")
+}
+
+func TestWriteProblem_Default(t *testing.T) {
+	w := httptest.NewRecorder()
+	errors.WriteProblem(w, nil, fmt.Errorf("internal error"))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Internal Server Error",
+		"status": 500,
+		"detail": "Internal Server Error"
+	}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	errors.WriteProblem(w, nil, fmt.Errorf("internal error"),
+		errors.ProblemDefaultStatus(http.StatusBadGateway),
+		errors.ProblemDefaultMessage("upstream failure"))
+	require.Equal(t, http.StatusBadGateway, w.Code)
+	require.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Gateway",
+		"status": 502,
+		"detail": "upstream failure"
+	}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	errors.WriteProblem(w, nil, errors.Errorf("test error", errors.HTTPStatus(599)))
+	require.Equal(t, 599, w.Code)
+	require.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Server Error",
+		"status": 599,
+		"detail": "Server Error"
+	}`, w.Body.String())
+
+	for _, status := range []int{42, http.StatusOK, http.StatusFound, 600} {
+		w = httptest.NewRecorder()
+		errors.WriteProblem(w, nil, errors.Errorf("test error", errors.HTTPStatus(status)),
+			errors.ProblemDefaultStatus(http.StatusBadGateway))
+		require.Equal(t, http.StatusBadGateway, w.Code)
+
+		w = httptest.NewRecorder()
+		errors.WriteProblem(w, nil, errors.Errorf("test error"), errors.ProblemDefaultStatus(status))
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+	}
+
+	require.PanicsWithValue(t, "nil error", func() { errors.WriteProblem(httptest.NewRecorder(), nil, nil) })
+}
+
+func TestWriteProblem_Debug(t *testing.T) {
+	w := httptest.NewRecorder()
+	errors.WriteProblem(w, nil, errors.Errorf("internal error"), errors.ProblemDebug(true))
+
+	m := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &m))
+	require.Equal(t, "internal error", m["debug"].(map[string]interface{})["error"])
+	require.Regexp(t, "^errors_test.TestWriteProblem_Debug", m["debug"].(map[string]interface{})["callers"].([]interface{})[0])
+}
+
+func TestWriteProblem_Compound(t *testing.T) {
+	err := errors.Append(
+		errors.Errorf("first error", errors.HTTPStatusNotFound, errors.PublicMessage("not found")),
+		errors.Errorf("second error", errors.HTTPStatusBadRequest, errors.ProblemExtension("field", "name")))
+
+	w := httptest.NewRecorder()
+	errors.WriteProblem(w, nil, err)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "Not Found",
+		"errors": [
+			{"type": "about:blank", "title": "Not Found", "status": 404, "detail": "not found"},
+			{"type": "about:blank", "title": "Bad Request", "status": 400, "detail": "Bad Request", "field": "name"}
+		]
+	}`, w.Body.String())
+}
+
+func TestNewProblem_Compound(t *testing.T) {
+	err := errors.Append(
+		errors.Errorf("first error", errors.HTTPStatusConflict),
+		errors.Wrap(io.EOF, errors.ErrorCode("user.not_found"),
+			errors.ProblemType("https://example.com/problems/user"), errors.ProblemExtension("userId", 42)))
+
+	p := errors.NewProblem(nil, err)
+	require.Equal(t, http.StatusConflict, p.Status)
+	require.Equal(t, "about:blank", p.Type)
+	require.Equal(t, "Conflict", p.Title)
+	require.Equal(t, "Conflict", p.Detail)
+	require.Nil(t, p.Extensions["code"])
+	require.Nil(t, p.Extensions["userId"])
+
+	inner := p.Extensions["errors"].([]*errors.Problem)
+	require.Equal(t, http.StatusConflict, inner[0].Status)
+	require.Equal(t, http.StatusNotFound, inner[1].Status)
+	require.Equal(t, "user.not_found", inner[1].Extensions["code"])
+	require.Equal(t, 42, inner[1].Extensions["userId"])
+
+	v := &errors.ValidationErrors{}
+	v.Add("/name", "must not be empty")
+	v.Add("/age", "must be positive", errors.HTTPStatusBadRequest)
+	require.Equal(t, http.StatusUnprocessableEntity, errors.NewProblem(nil, v.Err()).Status)
+
+	p = errors.NewProblem(nil, errors.Append(fmt.Errorf("first error"), fmt.Errorf("second error")),
+		errors.ProblemDefaultStatus(http.StatusBadGateway), errors.ProblemDefaultMessage("upstream failure"))
+	require.Equal(t, http.StatusBadGateway, p.Status)
+	require.Equal(t, "upstream failure", p.Detail)
+}
@@ -0,0 +1,164 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var errorCodeKey = NewKey[string]("errors.ErrorCode")
+
+// ErrorCodeDefinition describes a stable, machine-readable application error code, such as "user.not_found".
+type ErrorCodeDefinition struct {
+	Code          string `json:"code"`
+	HTTPStatus    int    `json:"httpStatus,omitempty"`
+	PublicMessage string `json:"publicMessage,omitempty"`
+	Description   string `json:"description,omitempty"`
+}
+
+// Registry maps application error codes to their definitions. It is safe for concurrent use.
+type Registry struct {
+	m           sync.RWMutex
+	definitions map[string]*ErrorCodeDefinition
+}
+
+// DefaultRegistry is the Registry used by RegisterErrorCode and ErrorCode.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		definitions: make(map[string]*ErrorCodeDefinition),
+	}
+}
+
+// Register adds the given definition to the registry, and returns an ErrorCode behavior for it. It panics if the code
+// is empty or already registered, so that conflicts are detected at initialization time.
+func (r *Registry) Register(definition ErrorCodeDefinition) Behavior {
+	if definition.Code == "" {
+		panic("empty error code")
+	}
+
+	r.add(&definition)
+	return r.ErrorCode(definition.Code)
+}
+
+// add adds the given definition to the registry, panicking if its code is already registered.
+func (r *Registry) add(definition *ErrorCodeDefinition) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if _, ok := r.definitions[definition.Code]; ok {
+		panic(fmt.Sprintf("duplicate error code: %v", definition.Code))
+	}
+
+	r.definitions[definition.Code] = definition
+}
+
+// Lookup returns the definition of the given code. It returns false if the code is not registered.
+func (r *Registry) Lookup(code string) (ErrorCodeDefinition, bool) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	if definition, ok := r.definitions[code]; ok {
+		return *definition, true
+	}
+	return ErrorCodeDefinition{}, false
+}
+
+// Definitions returns all definitions in the registry, sorted by code.
+func (r *Registry) Definitions() []ErrorCodeDefinition {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	definitions := make([]ErrorCodeDefinition, 0, len(r.definitions))
+	for _, definition := range r.definitions {
+		definitions = append(definitions, *definition)
+	}
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Code < definitions[j].Code
+	})
+
+	return definitions
+}
+
+// ErrorCode returns a behavior that stores the given application error code in the error metadata, together with the
+// default HTTP status and public message of its definition. Explicit HTTPStatus and PublicMessage behaviors applied
+// afterwards take precedence. It panics if the code is not registered.
+func (r *Registry) ErrorCode(code string) Behavior {
+	definition, ok := r.Lookup(code)
+	if !ok {
+		panic(fmt.Sprintf("unknown error code: %v", code))
+	}
+
+	behaviors := []Behavior{errorCodeKey.With(code)}
+	if definition.HTTPStatus != 0 {
+		behaviors = append(behaviors, HTTPStatus(definition.HTTPStatus))
+	}
+	if definition.PublicMessage != "" {
+		behaviors = append(behaviors, PublicMessage(definition.PublicMessage))
+	}
+
+	return Behaviors(behaviors...)
+}
+
+// MarshalJSON implements json.Marshaler. The registry is encoded as an array of definitions, sorted by code.
+func (r *Registry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Definitions())
+}
+
+// Markdown returns a Markdown table documenting all definitions in the registry, sorted by code.
+func (r *Registry) Markdown() string {
+	b := strings.Builder{}
+	b.WriteString("| Code | HTTP Status | Public Message | Description |\n")
+	b.WriteString("| ---- | ----------- | -------------- | ----------- |\n")
+
+	for _, definition := range r.Definitions() {
+		status := ""
+		if definition.HTTPStatus != 0 {
+			status = fmt.Sprintf("%v %v", definition.HTTPStatus, http.StatusText(definition.HTTPStatus))
+		}
+
+		fmt.Fprintf(&b, "| `%v` | %v | %v | %v |\n",
+			definition.Code,
+			status,
+			escapeMarkdown(definition.PublicMessage),
+			escapeMarkdown(definition.Description))
+	}
+
+	return b.String()
+}
+
+// RegisterErrorCode is like Registry.Register, on the DefaultRegistry.
+func RegisterErrorCode(definition ErrorCodeDefinition) Behavior {
+	return DefaultRegistry.Register(definition)
+}
+
+// ErrorCode is like Registry.ErrorCode, on the DefaultRegistry.
+func ErrorCode(code string) Behavior {
+	return DefaultRegistry.ErrorCode(code)
+}
+
+// GetErrorCode extracts an application error code from the error metadata, if any.
+// It returns "" if no application error code was set.
+func GetErrorCode(err error) string {
+	return errorCodeKey.GetOrDefault(err, "")
+}
+
+// GetErrorCodeOrDefault extracts an application error code from the error metadata, if any.
+// It returns the given default code if no application error code was set.
+func GetErrorCodeOrDefault(err error, defaultCode string) string {
+	if code := GetErrorCode(err); code != "" {
+		return code
+	}
+	return defaultCode
+}
+
+// escapeMarkdown escapes characters that would break a Markdown table cell.
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,105 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var errCodeUserNotFound = errors.RegisterErrorCode(errors.ErrorCodeDefinition{
+	Code:          "user.not_found",
+	HTTPStatus:    http.StatusNotFound,
+	PublicMessage: "user not found",
+	Description:   "The requested user does not exist.",
+})
+
+func ExampleRegisterErrorCode() {
+	// var errCodeUserNotFound = errors.RegisterErrorCode(errors.ErrorCodeDefinition{
+	//   Code:          "user.not_found",
+	//   HTTPStatus:    http.StatusNotFound,
+	//   PublicMessage: "user not found",
+	//   Description:   "The requested user does not exist.",
+	// })
+
+	err := errors.Errorf("user %v not found", 1234, errCodeUserNotFound)
+
+	fmt.Println(errors.GetErrorCode(err))
+	fmt.Println(errors.GetHTTPStatus(err))
+	fmt.Println(errors.GetPublicMessage(err))
+
+	// Output:
+	// user.not_found
+	// 404
+	// user not found
+}
+
+func TestRegistry(t *testing.T) {
+	r := errors.NewRegistry()
+	conflict := r.Register(errors.ErrorCodeDefinition{
+		Code:       "user.conflict",
+		HTTPStatus: http.StatusConflict,
+	})
+	r.Register(errors.ErrorCodeDefinition{
+		Code:          "auth.failed",
+		PublicMessage: "authentication | failed",
+		Description:   "The credentials\nare invalid.",
+	})
+
+	require.PanicsWithValue(t, "duplicate error code: user.conflict", func() {
+		r.Register(errors.ErrorCodeDefinition{Code: "user.conflict"})
+	})
+	require.PanicsWithValue(t, "empty error code", func() {
+		r.Register(errors.ErrorCodeDefinition{})
+	})
+	require.PanicsWithValue(t, "unknown error code: unknown", func() {
+		r.ErrorCode("unknown")
+	})
+
+	err := errors.Errorf("test error", conflict, errors.PublicMessage("already exists"))
+	require.Equal(t, "user.conflict", errors.GetErrorCode(err))
+	require.Equal(t, http.StatusConflict, errors.GetHTTPStatus(err))
+	require.Equal(t, "already exists", errors.GetPublicMessage(err))
+
+	err = errors.Errorf("test error", r.ErrorCode("auth.failed"))
+	require.Equal(t, "auth.failed", errors.GetErrorCodeOrDefault(err, "unknown"))
+	require.Equal(t, 0, errors.GetHTTPStatus(err))
+	require.Equal(t, "unknown", errors.GetErrorCodeOrDefault(errors.Errorf("test error"), "unknown"))
+
+	definition, ok := r.Lookup("auth.failed")
+	require.True(t, ok)
+	require.Equal(t, "authentication | failed", definition.PublicMessage)
+	_, ok = r.Lookup("unknown")
+	require.False(t, ok)
+
+	buf, jsonErr := json.Marshal(r)
+	require.NoError(t, jsonErr)
+	require.JSONEq(t, `[
+		{"code": "auth.failed", "publicMessage": "authentication | failed", "description": "The credentials\nare invalid."},
+		{"code": "user.conflict", "httpStatus": 409}
+	]`, string(buf))
+
+	require.Equal(t, ""+
+		"| Code | HTTP Status | Public Message | Description |\n"+
+		"| ---- | ----------- | -------------- | ----------- |\n"+
+		"| `auth.failed` |  | authentication \\| failed | The credentials are invalid. |\n"+
+		"| `user.conflict` | 409 Conflict |  |  |\n",
+		r.Markdown())
+}
+
+func TestRegistry_Problem(t *testing.T) {
+	w := httptest.NewRecorder()
+	errors.WriteProblem(w, nil, errors.Errorf("test error", errors.ErrorCode("user.not_found")))
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "user not found",
+		"code": "user.not_found"
+	}`, w.Body.String())
+}
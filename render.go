@@ -0,0 +1,124 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// CompoundRenderer describes how the message of a compound error is rendered from the messages of its inner errors.
+type CompoundRenderer struct {
+	// Header is written before the inner errors, e.g. "multiple errors: ".
+	Header string
+	// Separator is written between inner errors, e.g. " · ". It is ignored if Numbered is set.
+	Separator string
+	// Item, if set, renders a single inner error given its (0-based) index. By default the inner error message is used.
+	Item func(i int, err error) string
+	// Numbered enables a multiline layout, in which each inner error is written on a separate line as "1. message".
+	// Trailing whitespace is trimmed from Header in such case.
+	Numbered bool
+	// MaxItems, if positive, is the maximum number of inner errors rendered. The remaining ones are summarized by a
+	// final item formatted with More.
+	MaxItems int
+	// More is the format of the final item summarizing the inner errors beyond MaxItems, given their count. If empty,
+	// "and %v more" is used.
+	More string
+}
+
+var (
+	defaultCompoundRenderer = &CompoundRenderer{
+		Header:    "multiple errors: ",
+		Separator: " · ",
+	}
+
+	compoundRendererKey   = NewKey[*CompoundRenderer]("errors.CompoundRenderer")
+	compoundRendererMutex sync.RWMutex
+	compoundRenderer      = defaultCompoundRenderer
+)
+
+// SetCompoundRenderer sets the renderer used globally for the message of compound errors. If r is nil, the default
+// renderer is restored, which produces messages like "multiple errors: first · second".
+func SetCompoundRenderer(r *CompoundRenderer) {
+	if r == nil {
+		r = defaultCompoundRenderer
+	}
+
+	compoundRendererMutex.Lock()
+	defer compoundRendererMutex.Unlock()
+	compoundRenderer = r
+}
+
+// WithCompoundRenderer returns a Behavior that overrides the global renderer (see SetCompoundRenderer) for a node (see
+// Nest). Compound errors created by Append carry no metadata of their own, so they always use the global renderer:
+// applying the behavior to one of them through Wrap stores it on its last inner error, where it has no effect unless
+// such error is a node. Use Nest to render a group of errors differently.
+func WithCompoundRenderer(r *CompoundRenderer) Behavior {
+	return compoundRendererKey.With(r)
+}
+
+// Render renders the message of a compound error with the given inner errors.
+func (r *CompoundRenderer) Render(errs []error) string {
+	b := strings.Builder{}
+
+	if r.Numbered {
+		b.WriteString(strings.TrimRight(r.Header, " \t"))
+	} else {
+		b.WriteString(r.Header)
+	}
+
+	items := len(errs)
+	if r.MaxItems > 0 && items > r.MaxItems {
+		items = r.MaxItems
+	}
+
+	for i := 0; i < items; i++ {
+		r.writeSeparator(&b, i)
+		if r.Item != nil {
+			b.WriteString(r.Item(i, errs[i]))
+		} else {
+			b.WriteString(errs[i].Error())
+		}
+	}
+
+	if more := len(errs) - items; more > 0 {
+		format := r.More
+		if format == "" {
+			format = "and %v more"
+		}
+
+		r.writeSeparator(&b, items)
+		fmt.Fprintf(&b, format, more)
+	}
+
+	return b.String()
+}
+
+// writeSeparator writes the separator or line prefix preceding the item with the given index.
+func (r *CompoundRenderer) writeSeparator(b *strings.Builder, i int) {
+	switch {
+	case r.Numbered:
+		if i > 0 || b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "%v. ", i+1)
+	case i > 0:
+		b.WriteString(r.Separator)
+	}
+}
+
+// renderCompound renders the message of the given compound error, using the renderer set on the given node (see Nest)
+// if any, or the global one otherwise. The node is nil for compound errors created by Append.
+func renderCompound(node *wrappedError, e wrappedErrors) string {
+	var r *CompoundRenderer
+	if node != nil {
+		r, _ = compoundRendererKey.Get(node)
+	}
+
+	if r == nil {
+		compoundRendererMutex.RLock()
+		r = compoundRenderer
+		compoundRendererMutex.RUnlock()
+	}
+
+	return r.Render(e.Unwrap())
+}
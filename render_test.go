@@ -0,0 +1,120 @@
+package errors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleWithCompoundRenderer() {
+	errs := []error{fmt.Errorf("first error"), fmt.Errorf("second error"), fmt.Errorf("third error")}
+
+	fmt.Println(errors.Nest(errs, errors.WithCompoundRenderer(&errors.CompoundRenderer{
+		Header:   "3 errors:",
+		Numbered: true,
+	})))
+
+	fmt.Println(errors.Nest(errs, errors.WithCompoundRenderer(&errors.CompoundRenderer{
+		Separator: "; ",
+		MaxItems:  2,
+	})))
+
+	// Output:
+	// 3 errors:
+	// 1. first error
+	// 2. second error
+	// 3. third error
+	// first error; second error; and 1 more
+}
+
+func TestCompoundRenderer(t *testing.T) {
+	errs := []error{fmt.Errorf("first error"), fmt.Errorf("second error"), fmt.Errorf("third error")}
+
+	require.Equal(t, "first errorsecond errorthird error", (&errors.CompoundRenderer{}).Render(errs))
+	require.Equal(t, "errors: [first error], [second error], [third error]", (&errors.CompoundRenderer{
+		Header:    "errors: ",
+		Separator: ", ",
+		Item: func(i int, err error) string {
+			return "[" + err.Error() + "]"
+		},
+	}).Render(errs))
+	require.Equal(t, "errors: first error | (2 omitted)", (&errors.CompoundRenderer{
+		Header:    "errors: ",
+		Separator: " | ",
+		MaxItems:  1,
+		More:      "(%v omitted)",
+	}).Render(errs))
+	require.Equal(t, "1. first error\n2. second error\n3. and 1 more", (&errors.CompoundRenderer{
+		Numbered: true,
+		MaxItems: 2,
+	}).Render(errs))
+	require.Equal(t, "multiple errors:\n1. first error\n2. second error", (&errors.CompoundRenderer{
+		Header:    "multiple errors: ",
+		Separator: " · ",
+		Numbered:  true,
+	}).Render(errs[:2]))
+}
+
+func TestSetCompoundRenderer(t *testing.T) {
+	defer errors.SetCompoundRenderer(nil)
+
+	err := errors.Append(fmt.Errorf("first error"), fmt.Errorf("second error"))
+	require.EqualError(t, err, "multiple errors: first error · second error")
+
+	errors.SetCompoundRenderer(&errors.CompoundRenderer{Header: "errors: ", Separator: "; "})
+	require.EqualError(t, err, "errors: first error; second error")
+	require.EqualError(t, errors.Nest([]error{err}, errors.WithCompoundRenderer(&errors.CompoundRenderer{Separator: ", "})),
+		"first error, second error")
+	require.True(t, strings.HasPrefix(fmt.Sprintf("%+v", err), "errors: first error; second error\n[1/2] first error\n"))
+
+	errors.SetCompoundRenderer(nil)
+	require.EqualError(t, err, "multiple errors: first error · second error")
+}
+
+func TestWithCompoundRenderer(t *testing.T) {
+	r := &errors.CompoundRenderer{Header: "errors: ", Separator: ", "}
+
+	err := errors.Nest([]error{fmt.Errorf("first error"), fmt.Errorf("second error")}, errors.WithCompoundRenderer(r))
+	require.EqualError(t, err, "errors: first error, second error")
+	require.EqualError(t, errors.Wrap(err, errors.Prefix("prefix")), "prefix: errors: first error, second error")
+	require.NotContains(t, fmt.Sprintf("%+v", err), "errors.CompoundRenderer")
+
+	errs := errors.Wrap(errors.Append(fmt.Errorf("first error"), fmt.Errorf("second error")), errors.WithCompoundRenderer(r))
+	require.EqualError(t, errs, "multiple errors: first error · second error")
+	require.EqualError(t, errors.Split(errs)[1], "second error")
+
+	node := errors.Nest([]error{
+		errors.Append(fmt.Errorf("first error"), fmt.Errorf("second error")),
+		errors.Nest([]error{fmt.Errorf("third error"), fmt.Errorf("fourth error")}),
+	}, errors.Prefix("batch"), errors.WithCompoundRenderer(r))
+	require.EqualError(t, node, "batch: errors: first error, second error, multiple errors: third error · fourth error")
+
+	paths := make([]string, 0)
+	errors.Walk(node, func(path []int, err error) bool {
+		paths = append(paths, err.Error())
+		return len(path) < 1
+	})
+	require.Equal(t, "batch: multiple errors: third error · fourth error", paths[3])
+}
+
+func TestWithCompoundRenderer_Scope(t *testing.T) {
+	r := &errors.CompoundRenderer{
+		Header:    "NODE[",
+		Separator: "|",
+	}
+
+	node := errors.Nest([]error{fmt.Errorf("a"), fmt.Errorf("b")}, errors.WithCompoundRenderer(r))
+	require.EqualError(t, node, "NODE[a|b")
+	require.EqualError(t, errors.Append(fmt.Errorf("x"), node), "multiple errors: x · NODE[a|b")
+	require.EqualError(t, errors.Append(node, fmt.Errorf("x")), "multiple errors: NODE[a|b · x")
+
+	leaf := errors.Wrap(fmt.Errorf("a"), errors.WithCompoundRenderer(r))
+	require.EqualError(t, leaf, "a")
+	require.EqualError(t, errors.Append(fmt.Errorf("x"), leaf), "multiple errors: x · a")
+
+	errs := errors.Wrap(errors.Append(fmt.Errorf("x"), leaf), errors.WithCompoundRenderer(r))
+	require.EqualError(t, errs, "multiple errors: x · a")
+}
@@ -0,0 +1,174 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	retryableKey  = NewKey[bool]("errors.Retryable")
+	retryAfterKey = NewKey[time.Duration]("errors.RetryAfter")
+)
+
+// RetryPolicy configures the Retry helper.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. If 0 or negative, attempts are only
+	// bounded by the context.
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay computed from InitialBackoff and Multiplier, as well as the delay returned by
+	// GetRetryAfter. If 0, delays are not capped.
+	MaxBackoff time.Duration
+	// Multiplier is the factor by which the delay grows after each attempt. If less than 1, 2 is used.
+	Multiplier float64
+	// Jitter is the fraction of each delay that is randomized, between 0 (no jitter) and 1 (full jitter).
+	Jitter float64
+}
+
+// DefaultRetryPolicy is the policy used by Retry when called with a nil policy.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// Retryable returns a Behavior that marks the error as retryable or not, overriding the classification of IsRetryable.
+func Retryable(retryable bool) Behavior {
+	return retryableKey.With(retryable)
+}
+
+// IsRetryable returns true if the operation that returned err can be retried. If the Retryable behavior was applied,
+// its value is returned. Otherwise errors that are or wrap context.DeadlineExceeded, net.Error values reporting
+// Timeout, and errors with HTTP status 429 or 503 (see GetHTTPStatus) are considered retryable.
+func IsRetryable(err error) bool {
+	if retryable, ok := retryableKey.Get(err); ok {
+		return retryable
+	}
+
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if stderrors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	switch GetHTTPStatus(err) {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryAfter returns a Behavior that stores the minimum delay before the operation that returned the error should be
+// retried.
+func RetryAfter(delay time.Duration) Behavior {
+	return retryAfterKey.With(delay)
+}
+
+// GetRetryAfter extracts the delay stored by RetryAfter, if any. Otherwise, if the error was decoded from a HTTP
+// response (see GetResponseInfo), the delay is parsed from its Retry-After header. It returns 0 if neither is set.
+func GetRetryAfter(err error) time.Duration {
+	if delay, ok := retryAfterKey.Get(err); ok {
+		return delay
+	}
+
+	if info := GetResponseInfo(err); info != nil {
+		return parseRetryAfter(info.Header.Get("Retry-After"))
+	}
+
+	return 0
+}
+
+// Retry calls fn until it succeeds, it returns an error that is not retryable (see IsRetryable), the maximum number of
+// attempts is reached, or ctx is done. Attempts are spaced by an exponential backoff with jitter, or by the delay
+// returned by GetRetryAfter if longer (capped by MaxBackoff). If policy is nil, DefaultRetryPolicy is used. If all
+// attempts fail, the returned error is a compound error (see Append) containing the failure of each attempt, followed
+// by the context error if ctx was done while waiting.
+func Retry(ctx context.Context, policy *RetryPolicy, fn func(ctx context.Context) error) error {
+	if policy == nil {
+		policy = DefaultRetryPolicy
+	}
+
+	var errs error
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		errs = Append(errs, err)
+
+		if !IsRetryable(err) || (policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) {
+			return errs
+		}
+
+		timer := time.NewTimer(policy.delay(attempt, err))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return Append(errs, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// delay computes the delay after the given (1-based) failed attempt.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	maxBackoff := float64(p.MaxBackoff)
+	if maxBackoff <= 0 {
+		maxBackoff = float64(math.MaxInt64 >> 1)
+	}
+
+	backoff := math.Min(float64(p.InitialBackoff)*math.Pow(multiplier, float64(attempt-1)), maxBackoff)
+
+	jitter := math.Min(math.Max(p.Jitter, 0), 1)
+	delay := time.Duration(backoff * (1 - jitter*rand.Float64()))
+
+	retryAfter := GetRetryAfter(err)
+	if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+		retryAfter = p.MaxBackoff
+	}
+
+	if retryAfter > delay {
+		return retryAfter
+	}
+	return delay
+}
+
+// parseRetryAfter parses the value of a Retry-After header, expressed either in seconds or as a HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(t); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,168 @@
+package errors_test
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testTimeoutError struct{}
+
+func (testTimeoutError) Error() string   { return "timeout" }
+func (testTimeoutError) Timeout() bool   { return true }
+func (testTimeoutError) Temporary() bool { return true }
+
+var _ net.Error = testTimeoutError{}
+
+func ExampleRetry() {
+	policy := &errors.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+	}
+
+	err := errors.Retry(context.Background(), policy, func(ctx context.Context) error {
+		return errors.Errorf("service unavailable", errors.HTTPStatusServiceUnavailable)
+	})
+
+	fmt.Println(err.Error())
+	fmt.Println(len(errors.Split(err)))
+
+	// Output:
+	// multiple errors: service unavailable · service unavailable · service unavailable
+	// 3
+}
+
+func TestIsRetryable(t *testing.T) {
+	require.False(t, errors.IsRetryable(errors.Errorf("test error")))
+	require.False(t, errors.IsRetryable(errors.Errorf("test error", errors.HTTPStatusNotFound)))
+	require.False(t, errors.IsRetryable(context.Canceled))
+	require.True(t, errors.IsRetryable(errors.Errorf("test error", errors.Retryable(true))))
+	require.True(t, errors.IsRetryable(errors.Errorf("test error", errors.HTTPStatusTooManyRequests)))
+	require.True(t, errors.IsRetryable(errors.Errorf("test error", errors.HTTPStatusServiceUnavailable)))
+	require.True(t, errors.IsRetryable(errors.Errorf("test error", errors.WithCode(errors.CodeUnavailable))))
+	require.False(t, errors.IsRetryable(errors.Errorf("test error", errors.HTTPStatusServiceUnavailable, errors.Retryable(false))))
+	require.True(t, errors.IsRetryable(context.DeadlineExceeded))
+	require.True(t, errors.IsRetryable(errors.Wrap(context.DeadlineExceeded)))
+	require.True(t, errors.IsRetryable(errors.Errorf("query: %w", context.DeadlineExceeded)))
+	require.True(t, errors.IsRetryable(errors.Wrap(testTimeoutError{})))
+	require.True(t, errors.IsRetryable(&net.OpError{Op: "dial", Err: testTimeoutError{}}))
+	require.True(t, errors.IsRetryable(errors.Append(errors.Errorf("test error"), errors.Errorf("test error", errors.Retryable(true)))))
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	require.Equal(t, time.Duration(0), errors.GetRetryAfter(errors.Errorf("test error")))
+	require.Equal(t, time.Minute, errors.GetRetryAfter(errors.Errorf("test error", errors.RetryAfter(time.Minute))))
+
+	resp := newTestResponse(http.StatusTooManyRequests, "text/plain", "")
+	resp.Header.Set("Retry-After", "120")
+	err := errors.WrapResponse(resp)
+	require.True(t, errors.IsRetryable(err))
+	require.Equal(t, 2*time.Minute, errors.GetRetryAfter(err))
+
+	resp = newTestResponse(http.StatusServiceUnavailable, "text/plain", "")
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	err = errors.WrapResponse(resp)
+	require.InDelta(t, time.Hour, errors.GetRetryAfter(err), float64(2*time.Second))
+
+	resp = newTestResponse(http.StatusServiceUnavailable, "text/plain", "")
+	resp.Header.Set("Retry-After", "invalid")
+	require.Equal(t, time.Duration(0), errors.GetRetryAfter(errors.WrapResponse(resp)))
+}
+
+func TestRetry(t *testing.T) {
+	policy := &errors.RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+		Jitter:         1,
+	}
+
+	attempts := 0
+	require.NoError(t, errors.Retry(context.Background(), policy, func(ctx context.Context) error {
+		if attempts++; attempts < 3 {
+			return errors.Errorf("attempt %v", attempts, errors.Retryable(true))
+		}
+		return nil
+	}))
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err := errors.Retry(context.Background(), policy, func(ctx context.Context) error {
+		attempts++
+		return errors.Errorf("attempt %v", attempts, errors.Retryable(attempts < 2))
+	})
+	require.EqualError(t, err, "multiple errors: attempt 1 · attempt 2")
+	require.Equal(t, 2, attempts)
+
+	attempts = 0
+	err = errors.Retry(context.Background(), policy, func(ctx context.Context) error {
+		attempts++
+		return errors.Errorf("attempt %v", attempts, errors.Retryable(true))
+	})
+	require.Len(t, errors.Split(err), 5)
+	require.Equal(t, 5, attempts)
+
+	attempts = 0
+	err = errors.Retry(context.Background(), policy, func(ctx context.Context) error {
+		attempts++
+		return errors.Errorf("test error")
+	})
+	require.EqualError(t, err, "test error")
+	require.Equal(t, 1, attempts)
+}
+
+func TestRetry_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policy := &errors.RetryPolicy{
+		InitialBackoff: time.Hour,
+	}
+
+	err := errors.Retry(ctx, policy, func(ctx context.Context) error {
+		cancel()
+		return errors.Errorf("test error", errors.Retryable(true))
+	})
+
+	require.EqualError(t, err, "multiple errors: test error · context canceled")
+	require.True(t, stderrors.Is(err, context.Canceled))
+}
+
+func TestRetry_RetryAfter(t *testing.T) {
+	policy := &errors.RetryPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+	}
+
+	start := time.Now()
+	err := errors.Retry(context.Background(), policy, func(ctx context.Context) error {
+		return errors.Errorf("test error", errors.HTTPStatusTooManyRequests, errors.RetryAfter(50*time.Millisecond))
+	})
+
+	require.Len(t, errors.Split(err), 2)
+	require.True(t, time.Since(start) >= 50*time.Millisecond)
+}
+
+func TestRetry_RetryAfterCapped(t *testing.T) {
+	policy := &errors.RetryPolicy{
+		MaxAttempts:    2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+
+	start := time.Now()
+	err := errors.Retry(context.Background(), policy, func(ctx context.Context) error {
+		return errors.Errorf("test error", errors.HTTPStatusTooManyRequests, errors.RetryAfter(24*time.Hour))
+	})
+
+	require.Len(t, errors.Split(err), 2)
+	require.True(t, time.Since(start) >= 10*time.Millisecond)
+	require.True(t, time.Since(start) < time.Second)
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	stderrors "errors"
+)
+
+// sentinelError is a template error carrying default behaviors, created by NewSentinel.
+type sentinelError struct {
+	message   string
+	behaviors []Behavior
+	defaults  *wrappedError
+}
+
+// Error implements error.
+func (e *sentinelError) Error() string {
+	return e.message
+}
+
+// NewSentinel creates a sentinel error, i.e. a template error meant to be declared at package level, that carries
+// default behaviors such as HTTPStatus, PublicMessage or WithCode. Whenever the sentinel is wrapped (directly, or
+// through another error such as one created by Errorf with the %w verb), its default behaviors are applied before the
+// given ones. Each occurrence gets its own stack trace, while Equals and errors.Is keep matching the sentinel. Getters
+// also work on the unwrapped sentinel itself.
+func NewSentinel(message string, behaviors ...Behavior) error {
+	e := &sentinelError{
+		message:   message,
+		behaviors: behaviors,
+	}
+
+	e.defaults = &wrappedError{
+		err:      e,
+		metadata: make(map[interface{}]interface{}),
+	}
+
+	Behaviors(behaviors...)(false, e.defaults)
+	return e
+}
+
+// sentinelBehaviors returns the default behaviors of the first sentinel error found in the chain of err, if any.
+func sentinelBehaviors(err error) []Behavior {
+	var e *sentinelError
+	if stderrors.As(err, &e) {
+		return e.behaviors
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNotFound = errors.NewSentinel("not found",
+	errors.HTTPStatusNotFound,
+	errors.PublicMessage("resource not found"),
+	errors.Metadata("key", "value"))
+
+func ExampleNewSentinel() {
+	// var errTestNotFound = errors.NewSentinel("not found",
+	// 	 errors.HTTPStatusNotFound,
+	// 	 errors.PublicMessage("resource not found"),
+	// 	 errors.Metadata("key", "value"))
+
+	err := errors.Errorf("user %v: %w", 1234, errTestNotFound)
+
+	fmt.Println(err.Error())
+	fmt.Println(errors.GetHTTPStatus(err))
+	fmt.Println(errors.GetPublicMessage(err))
+	fmt.Println(stderrors.Is(err, errTestNotFound))
+
+	// Output:
+	// user 1234: not found
+	// 404
+	// resource not found
+	// true
+}
+
+func TestNewSentinel(t *testing.T) {
+	require.Equal(t, "not found", errTestNotFound.Error())
+	require.Equal(t, http.StatusNotFound, errors.GetHTTPStatus(errTestNotFound))
+	require.Nil(t, errors.GetCallers(errTestNotFound))
+
+	err := errors.Wrap(errTestNotFound, errors.PublicMessage("user not found"))
+	require.Equal(t, "not found", err.Error())
+	require.Equal(t, http.StatusNotFound, errors.GetHTTPStatus(err))
+	require.Equal(t, "user not found", errors.GetPublicMessage(err))
+	require.Equal(t, "value", errors.GetMetadata(err, "key"))
+	require.True(t, strings.HasPrefix(errors.FormatCallers(errors.GetCallers(err))[0], "errors_test.TestNewSentinel"))
+	require.True(t, errors.Equals(err, errTestNotFound))
+	require.True(t, stderrors.Is(err, errTestNotFound))
+
+	err2 := errors.Wrap(errTestNotFound)
+	require.NotEqual(t, errors.GetCallers(err), errors.GetCallers(err2))
+	require.Equal(t, "resource not found", errors.GetPublicMessage(err2))
+
+	err = errors.Errorf("wrapped: %w", errTestNotFound, errors.HTTPStatusGone)
+	require.Equal(t, http.StatusGone, errors.GetHTTPStatus(err))
+	require.Equal(t, "resource not found", errors.GetPublicMessage(err))
+	require.True(t, errors.Equals(err, errTestNotFound))
+	require.True(t, errors.Equals(fmt.Errorf("outer: %w", err), errTestNotFound))
+	require.True(t, stderrors.Is(err, errTestNotFound))
+
+	err = errors.Append(errors.Errorf("first error"), errTestNotFound)
+	require.Equal(t, http.StatusNotFound, errors.GetHTTPStatus(err))
+	require.True(t, errors.Equals(err, errTestNotFound))
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Severity classifies errors for logging and alerting purposes.
+type Severity int
+
+// Known severities, in increasing order.
+const (
+	// SeverityDebug denotes errors only relevant while debugging.
+	SeverityDebug Severity = iota
+	// SeverityInfo denotes expected errors, e.g. validation failures.
+	SeverityInfo
+	// SeverityWarning denotes errors that may require attention, e.g. client errors.
+	SeverityWarning
+	// SeverityError denotes errors that require attention, e.g. server errors.
+	SeverityError
+	// SeverityCritical denotes errors that require immediate attention.
+	SeverityCritical
+)
+
+var severityKey = NewKey[Severity]("errors.Severity")
+
+var severityNames = map[Severity]string{
+	SeverityDebug:    "Debug",
+	SeverityInfo:     "Info",
+	SeverityWarning:  "Warning",
+	SeverityError:    "Error",
+	SeverityCritical: "Critical",
+}
+
+// String implements fmt.Stringer.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return "Severity(" + strconv.Itoa(int(s)) + ")"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names are matched case-insensitively.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range severityNames {
+		if strings.EqualFold(name, string(text)) {
+			*s = severity
+			return nil
+		}
+	}
+	return Errorf("unknown severity: %q", string(text))
+}
+
+// Level returns the slog.Level corresponding to the severity. SeverityCritical maps to a level above slog.LevelError.
+func (s Severity) Level() slog.Level {
+	switch s {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarning:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError
+	}
+}
+
+// WithSeverity returns a behavior that stores a severity in the error metadata.
+func WithSeverity(severity Severity) Behavior {
+	return severityKey.With(severity)
+}
+
+// GetSeverity extracts a severity from the error metadata, if any. If no severity was set, it is inferred from the
+// HTTP status (see GetHTTPStatus): SeverityWarning for 4xx statuses, SeverityError otherwise. If err is a compound
+// error, or a node (see Nest) without an explicit severity, the maximum severity across its inner errors is returned.
+func GetSeverity(err error) Severity {
+	if _, ok := err.(wrappedErrors); !ok {
+		if severity, ok := severityKey.Get(err); ok {
+			return severity
+		}
+	}
+
+	if inner := children(err); inner != nil {
+		severity := SeverityDebug
+		for _, wErr := range inner {
+			if s := GetSeverity(wErr); s > severity {
+				severity = s
+			}
+		}
+		return severity
+	}
+
+	if status := GetHTTPStatus(err); status >= 400 && status <= 499 {
+		return SeverityWarning
+	}
+
+	return SeverityError
+}
+
+// LogError logs err using the given logger, at the level corresponding to its severity (see GetSeverity). The error is
+// stored in an "error" attribute, followed by the given arguments as in slog.Logger.Log.
+func LogError(ctx context.Context, logger *slog.Logger, msg string, err error, args ...interface{}) {
+	level := GetSeverity(err).Level()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+
+	record := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	record.AddAttrs(slog.Any("error", err))
+	record.Add(args...)
+	Ignore(logger.Handler().Handle(ctx, record))
+}
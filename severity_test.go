@@ -0,0 +1,79 @@
+package errors_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleLogError() {
+	buf := &bytes.Buffer{}
+	logger := newTestLogger(buf, nil)
+
+	errors.LogError(context.Background(), logger, "request failed", errors.Errorf("test error", errors.HTTPStatusNotFound))
+	errors.LogError(context.Background(), logger, "request failed", errors.Errorf("test error", errors.WithSeverity(errors.SeverityCritical)))
+
+	fmt.Print(buf.String())
+
+	// Output:
+	// {"level":"WARN","msg":"request failed","error":{"message":"test error","httpStatus":404}}
+	// {"level":"ERROR+4","msg":"request failed","error":{"message":"test error","metadata":{"errors.Severity":"Critical"}}}
+}
+
+func TestSeverity(t *testing.T) {
+	require.Equal(t, "Warning", errors.SeverityWarning.String())
+	require.Equal(t, "Severity(10)", errors.Severity(10).String())
+	require.Equal(t, slog.LevelDebug, errors.SeverityDebug.Level())
+	require.Equal(t, slog.LevelInfo, errors.SeverityInfo.Level())
+	require.Equal(t, slog.LevelWarn, errors.SeverityWarning.Level())
+	require.Equal(t, slog.LevelError, errors.SeverityError.Level())
+	require.Equal(t, slog.LevelError+4, errors.SeverityCritical.Level())
+
+	buf, err := json.Marshal(errors.SeverityInfo)
+	require.NoError(t, err)
+	require.Equal(t, `"Info"`, string(buf))
+
+	var severity errors.Severity
+	require.NoError(t, json.Unmarshal([]byte(`"critical"`), &severity))
+	require.Equal(t, errors.SeverityCritical, severity)
+	require.EqualError(t, severity.UnmarshalText([]byte("unknown")), `unknown severity: "unknown"`)
+}
+
+func TestGetSeverity(t *testing.T) {
+	require.Equal(t, errors.SeverityError, errors.GetSeverity(fmt.Errorf("test error")))
+	require.Equal(t, errors.SeverityError, errors.GetSeverity(errors.Errorf("test error")))
+	require.Equal(t, errors.SeverityWarning, errors.GetSeverity(errors.Errorf("test error", errors.HTTPStatusBadRequest)))
+	require.Equal(t, errors.SeverityError, errors.GetSeverity(errors.Errorf("test error", errors.HTTPStatusBadGateway)))
+	require.Equal(t, errors.SeverityWarning, errors.GetSeverity(errors.Errorf("test error", errors.WithCode(errors.CodeNotFound))))
+	require.Equal(t, errors.SeverityInfo, errors.GetSeverity(errors.Errorf("test error", errors.HTTPStatusBadRequest, errors.WithSeverity(errors.SeverityInfo))))
+
+	err := errors.Append(
+		errors.Errorf("first error", errors.WithSeverity(errors.SeverityDebug)),
+		errors.Errorf("second error", errors.HTTPStatus(http.StatusConflict)))
+	require.Equal(t, errors.SeverityWarning, errors.GetSeverity(err))
+
+	err = errors.Append(err, errors.Errorf("third error", errors.WithSeverity(errors.SeverityCritical)))
+	require.Equal(t, errors.SeverityCritical, errors.GetSeverity(err))
+}
+
+func TestLogError(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo, AddSource: true}))
+
+	errors.LogError(context.Background(), logger, "test", errors.Errorf("test error", errors.WithSeverity(errors.SeverityDebug)))
+	require.Empty(t, buf.String())
+
+	errors.LogError(context.Background(), logger, "test", errors.Errorf("test error", errors.WithSeverity(errors.SeverityInfo)), "key", "value")
+	m := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	require.Equal(t, "INFO", m["level"])
+	require.Equal(t, "value", m["key"])
+	require.Equal(t, "github.com/ibrt/errors_test.TestLogError", m["source"].(map[string]interface{})["function"])
+}
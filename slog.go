@@ -0,0 +1,193 @@
+package errors
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"strconv"
+)
+
+// LogOptions customizes how errors are converted to slog values.
+type LogOptions struct {
+	// StackDepth is the maximum number of stack frames included in the "stack" attribute. If 0, the stack trace is
+	// omitted. If negative, the full stack trace is included.
+	StackDepth int
+	// Redact, if set, is invoked on each attribute of the error group, and can replace its value.
+	Redact func(key string, value slog.Value) slog.Value
+}
+
+// DefaultLogOptions are the options used by the LogValue methods of wrapped and compound errors.
+var DefaultLogOptions = &LogOptions{}
+
+// LogValue implements slog.LogValuer, using DefaultLogOptions.
+func (e *wrappedError) LogValue() slog.Value {
+	return DefaultLogOptions.Value(e)
+}
+
+// LogValue implements slog.LogValuer, using DefaultLogOptions.
+func (e wrappedErrors) LogValue() slog.Value {
+	return DefaultLogOptions.Value(e)
+}
+
+// Value converts the given error to a slog group value containing the message, prefix, HTTP status, public message,
+// loggable metadata and (optionally) stack trace. If err is a compound error, the group contains the message and an
+// "errors" group with an entry for each inner error. If err is a node (see Nest), the "errors" group is added to the
+// attributes of the node.
+func (o *LogOptions) Value(err error) slog.Value {
+	if wErrs, ok := err.(wrappedErrors); ok {
+		return slog.GroupValue(
+			o.attr("message", slog.StringValue(wErrs.Error())),
+			o.errorsAttr(wErrs))
+	}
+
+	attrs := []slog.Attr{o.attr("message", slog.StringValue(err.Error()))}
+
+	wErr, ok := err.(*wrappedError)
+	if !ok {
+		return slog.GroupValue(attrs...)
+	}
+
+	if prefix := GetPrefix(wErr); prefix != "" {
+		attrs = append(attrs, o.attr("prefix", slog.StringValue(prefix)))
+	}
+	if status := GetHTTPStatus(wErr); status != 0 {
+		attrs = append(attrs, o.attr("httpStatus", slog.IntValue(status)))
+	}
+	if message := GetPublicMessage(wErr); message != "" {
+		attrs = append(attrs, o.attr("publicMessage", slog.StringValue(message)))
+	}
+
+	if metadata := userMetadata(wErr); len(metadata) > 0 {
+		metadataAttrs := make([]slog.Attr, 0, len(metadata))
+		for key, value := range metadata {
+			if isLoggable(value) {
+				metadataAttrs = append(metadataAttrs, o.attr(formatMetadataKey(key), slog.AnyValue(value)))
+			}
+		}
+		if len(metadataAttrs) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "metadata", Value: slog.GroupValue(metadataAttrs...)})
+		}
+	}
+
+	if o.StackDepth != 0 {
+		if callers := GetFormattedCallers(wErr); len(callers) > 0 {
+			if o.StackDepth > 0 && len(callers) > o.StackDepth {
+				callers = callers[:o.StackDepth]
+			}
+			attrs = append(attrs, o.attr("stack", slog.AnyValue(callers)))
+		}
+	}
+
+	if inner := children(wErr); inner != nil {
+		attrs = append(attrs, o.errorsAttr(inner))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// errorsAttr builds the "errors" group describing the given inner errors.
+func (o *LogOptions) errorsAttr(wErrs wrappedErrors) slog.Attr {
+	attrs := make([]slog.Attr, 0, len(wErrs))
+	for i, wErr := range wErrs {
+		attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: o.Value(wErr)})
+	}
+	return slog.Attr{Key: "errors", Value: slog.GroupValue(attrs...)}
+}
+
+// attr builds a slog.Attr, applying the redaction hook if set.
+func (o *LogOptions) attr(key string, value slog.Value) slog.Attr {
+	if o.Redact != nil {
+		value = o.Redact(key, value)
+	}
+	return slog.Attr{Key: key, Value: value}
+}
+
+// isLoggable returns true if the given metadata value can be meaningfully logged.
+func isLoggable(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	default:
+		return true
+	}
+}
+
+// LogHandler is a slog.Handler decorator that expands error-valued attributes using its LogOptions.
+type LogHandler struct {
+	next    slog.Handler
+	options *LogOptions
+}
+
+// NewLogHandler creates a new LogHandler that expands error-valued attributes before passing records to next. If
+// options is nil, DefaultLogOptions are used.
+func NewLogHandler(next slog.Handler, options *LogOptions) *LogHandler {
+	if options == nil {
+		options = DefaultLogOptions
+	}
+
+	return &LogHandler{
+		next:    next,
+		options: options,
+	}
+}
+
+// Enabled implements slog.Handler.
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	expanded := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		expanded.AddAttrs(h.expand(attr))
+		return true
+	})
+
+	return h.next.Handle(ctx, expanded)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		expanded = append(expanded, h.expand(attr))
+	}
+
+	return &LogHandler{
+		next:    h.next.WithAttrs(expanded),
+		options: h.options,
+	}
+}
+
+// WithGroup implements slog.Handler.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{
+		next:    h.next.WithGroup(name),
+		options: h.options,
+	}
+}
+
+// expand replaces error values in the given attribute, recursing into groups.
+func (h *LogHandler) expand(attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindAny, slog.KindLogValuer:
+		if err, ok := attr.Value.Any().(error); ok && err != nil {
+			return slog.Attr{Key: attr.Key, Value: h.options.Value(err)}
+		}
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		expanded := make([]slog.Attr, 0, len(group))
+		for _, attr := range group {
+			expanded = append(expanded, h.expand(attr))
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+	}
+
+	return attr
+}
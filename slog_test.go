@@ -0,0 +1,93 @@
+package errors_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(buf *bytes.Buffer, options *errors.LogOptions) *slog.Logger {
+	return slog.New(errors.NewLogHandler(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}), options))
+}
+
+func ExampleNewLogHandler() {
+	buf := &bytes.Buffer{}
+	logger := newTestLogger(buf, nil)
+
+	logger.Error("request failed", "err", errors.Errorf("test error",
+		errors.Prefix("prefix"),
+		errors.HTTPStatusNotFound,
+		errors.Metadata("key", "value")))
+
+	fmt.Print(buf.String())
+
+	// Output:
+	// {"level":"ERROR","msg":"request failed","err":{"message":"prefix: test error","prefix":"prefix: ","httpStatus":404,"metadata":{"key":"value"}}}
+}
+
+func TestLogValue(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+
+	logger.Info("test", "err", errors.Errorf("test error", errors.PublicMessage("public"), errors.Metadata("fn", func() {})))
+	m := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	require.Equal(t, map[string]interface{}{"message": "test error", "publicMessage": "public"}, m["err"])
+
+	buf.Reset()
+	logger.Info("test", "err", errors.Append(errors.Errorf("first error"), errors.Errorf("second error")))
+	m = map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	require.Equal(t, map[string]interface{}{
+		"message": "multiple errors: first error · second error",
+		"errors": map[string]interface{}{
+			"0": map[string]interface{}{"message": "first error"},
+			"1": map[string]interface{}{"message": "second error"},
+		},
+	}, m["err"])
+}
+
+func TestLogHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := newTestLogger(buf, &errors.LogOptions{
+		StackDepth: 1,
+		Redact: func(key string, value slog.Value) slog.Value {
+			if key == "secret" {
+				return slog.StringValue("[redacted]")
+			}
+			return value
+		},
+	})
+
+	logger = logger.With("base", fmt.Errorf("plain error")).WithGroup("group")
+	logger.Error("test",
+		slog.Group("nested", "err", errors.Errorf("test error", errors.HTTPStatus(http.StatusBadGateway), errors.Metadata("secret", "value"))))
+
+	m := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	require.Equal(t, map[string]interface{}{"message": "plain error"}, m["base"])
+
+	err := m["group"].(map[string]interface{})["nested"].(map[string]interface{})["err"].(map[string]interface{})
+	require.Equal(t, "test error", err["message"])
+	require.Equal(t, float64(http.StatusBadGateway), err["httpStatus"])
+	require.Equal(t, map[string]interface{}{"secret": "[redacted]"}, err["metadata"])
+	require.Len(t, err["stack"], 1)
+	require.True(t, strings.HasPrefix(err["stack"].([]interface{})[0].(string), "errors_test.TestLogHandler"))
+
+	require.True(t, logger.Handler().Enabled(context.Background(), slog.LevelInfo))
+}
@@ -0,0 +1,116 @@
+package errors
+
+// Nest creates a tree-shaped compound error, i.e. a node holding the given errors as children. Unlike compound errors
+// created by Append, a node is itself a wrapped error: the given behaviors (and any later Wrap) apply to the node,
+// which can thus carry its own prefix, HTTP status and metadata. Children can be unwrapped, wrapped, compound errors
+// (whose inner errors are added as separate children), or nodes. Append treats a node as a single inner error, so the
+// tree structure is preserved. Getters on a node only consider its own metadata. Use Walk to traverse the tree.
+func Nest(errs []error, behaviors ...Behavior) error {
+	if len(errs) == 0 {
+		panic("no errors")
+	}
+
+	var inner wrappedErrors
+
+	for _, child := range errs {
+		if child == nil {
+			panic("nil error")
+		}
+
+		switch child := child.(type) {
+		case *wrappedError:
+			inner = append(inner, child)
+		case wrappedErrors:
+			inner = append(inner, child...)
+		default:
+			inner = append(inner, Wrap(child, Skip(1)).(*wrappedError))
+		}
+	}
+
+	wErr := &wrappedError{
+		err:      inner,
+		metadata: make(map[interface{}]interface{}),
+	}
+
+	behaviors = append([]Behavior{Callers(), Skip(2)}, behaviors...)
+	Behaviors(behaviors...)(false, wErr)
+	finalizeCallers(wErr)
+	return wErr
+}
+
+// Walk traverses the tree of err in depth-first order, calling fn for err itself (with an empty path) and each of its
+// descendants. The path holds the index of each node along the way, e.g. [1 0] is the first child of the second child
+// of err. Descendants are the inner errors of compound errors (see Append) and the children of nodes (see Nest). If fn
+// returns false, the descendants of the current error are skipped.
+//
+// Descendants are passed to fn as a view that resolves getters along the path: metadata is looked up in the descendant
+// first, then in its ancestors, from the closest to the root. The prefixes of the ancestors are prepended to the one of
+// the descendant, so that the message of the view describes the full path. Stack traces are never inherited.
+func Walk(err error, fn func(path []int, err error) bool) {
+	if err == nil {
+		panic("nil error")
+	}
+
+	walk(nil, err, nil, fn)
+}
+
+// walk implements Walk. The given ancestors are the wrapped errors along the path, from the root.
+func walk(path []int, err error, ancestors []*wrappedError, fn func(path []int, err error) bool) {
+	view := err
+	if wErr, ok := err.(*wrappedError); ok && len(ancestors) > 0 {
+		view = newPathView(wErr, ancestors)
+	}
+
+	if !fn(append([]int{}, path...), view) {
+		return
+	}
+
+	if wErr, ok := err.(*wrappedError); ok {
+		ancestors = append(ancestors[:len(ancestors):len(ancestors)], wErr)
+	}
+
+	for i, child := range children(err) {
+		walk(append(path[:len(path):len(path)], i), child, ancestors, fn)
+	}
+}
+
+// children returns the inner errors of a compound error or node, or nil if err is neither.
+func children(err error) wrappedErrors {
+	switch err := err.(type) {
+	case *wrappedError:
+		if inner, ok := err.err.(wrappedErrors); ok {
+			return inner
+		}
+	case wrappedErrors:
+		return err
+	}
+	return nil
+}
+
+// newPathView builds a single-layer wrapped error that resolves the metadata of e along the given ancestors.
+func newPathView(e *wrappedError, ancestors []*wrappedError) *wrappedError {
+	view := &wrappedError{
+		err:      e.err,
+		metadata: make(map[interface{}]interface{}),
+	}
+
+	prefix := ""
+	for _, ancestor := range ancestors {
+		prefix += GetPrefix(ancestor)
+		for key, value := range ancestor.flatMetadata() {
+			if !isInternalKey(key) {
+				view.metadata[key] = value
+			}
+		}
+	}
+
+	for key, value := range e.flatMetadata() {
+		view.metadata[key] = value
+	}
+
+	if prefix += GetPrefix(e); prefix != "" {
+		view.metadata[prefixKey] = prefix
+	}
+
+	return view
+}
@@ -0,0 +1,206 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTree() (error, error) {
+	errItem := fmt.Errorf("item error")
+
+	return errors.Nest([]error{
+		errors.Nest([]error{
+			errItem,
+			errors.Errorf("other error", errors.Prefix("item 2"), errors.Metadata("k", "item")),
+		}, errors.Prefix("sub-batch 1"), errors.HTTPStatusConflict, errors.Metadata("k", "sub-batch")),
+		fmt.Errorf("last error"),
+	}, errors.Prefix("batch"), errors.Metadata("batch", 1)), errItem
+}
+
+func ExampleWalk() {
+	err, _ := newTestTree()
+
+	errors.Walk(err, func(path []int, err error) bool {
+		fmt.Println(path, err.Error(), errors.GetHTTPStatus(err))
+		return true
+	})
+
+	// Output:
+	// [] batch: multiple errors: sub-batch 1: multiple errors: item error · item 2: other error · last error 0
+	// [0] batch: sub-batch 1: multiple errors: item error · item 2: other error 409
+	// [0 0] batch: sub-batch 1: item error 409
+	// [0 1] batch: sub-batch 1: item 2: other error 409
+	// [1] batch: last error 0
+}
+
+func TestNest(t *testing.T) {
+	require.PanicsWithValue(t, "no errors", func() {
+		_ = errors.Nest(nil)
+	})
+	require.PanicsWithValue(t, "nil error", func() {
+		_ = errors.Nest([]error{nil})
+	})
+
+	err, errItem := newTestTree()
+	require.Equal(t, "batch: ", errors.GetPrefix(err))
+	require.Equal(t, 0, errors.GetHTTPStatus(err))
+	require.Equal(t, 1, errors.GetMetadata(err, "batch"))
+	require.Nil(t, errors.GetMetadata(err, "k"))
+	require.True(t, strings.HasPrefix(errors.GetFormattedCallers(err)[0], "errors_test.newTestTree"))
+	require.Len(t, errors.Split(err), 1)
+
+	require.True(t, errors.Equals(err, errItem))
+	require.True(t, errors.Equals(errItem, err))
+	require.True(t, stderrors.Is(err, errItem))
+	require.False(t, errors.Equals(err, fmt.Errorf("item error")))
+
+	err = errors.Wrap(err, errors.HTTPStatusBadGateway)
+	require.Equal(t, http.StatusBadGateway, errors.GetHTTPStatus(err))
+
+	err = errors.Nest([]error{errors.Append(fmt.Errorf("first error"), fmt.Errorf("second error")), fmt.Errorf("third error")})
+	require.EqualError(t, err, "multiple errors: first error · second error · third error")
+
+	count := 0
+	errors.Walk(err, func(path []int, err error) bool {
+		if len(path) == 1 && path[0] == 2 {
+			require.True(t, strings.HasPrefix(errors.GetFormattedCallers(err)[0], "errors_test.TestNest"))
+		}
+		count++
+		return true
+	})
+	require.Equal(t, 4, count)
+}
+
+func TestNest_Append(t *testing.T) {
+	err, errItem := newTestTree()
+	err = errors.Append(err, fmt.Errorf("other batch error"))
+
+	errs := errors.Split(err)
+	require.Len(t, errs, 2)
+	require.Equal(t, "batch: ", errors.GetPrefix(errs[0]))
+	require.True(t, errors.Equals(err, errItem))
+	require.Equal(t, errors.SeverityError, errors.GetSeverity(errs[0]))
+	require.Equal(t, errors.SeverityInfo, errors.GetSeverity(errors.Wrap(errs[0], errors.WithSeverity(errors.SeverityInfo))))
+
+	paths := make([]string, 0)
+	errors.Walk(err, func(path []int, err error) bool {
+		paths = append(paths, fmt.Sprint(path))
+		return len(path) < 2
+	})
+	require.Equal(t, []string{"[]", "[0]", "[0 0]", "[0 1]", "[1]"}, paths)
+
+	paths = paths[:0]
+	errors.Walk(err, func(path []int, err error) bool {
+		paths = append(paths, fmt.Sprint(path))
+		return len(path) < 1
+	})
+	require.Equal(t, []string{"[]", "[0]", "[1]"}, paths)
+}
+
+func TestWalk(t *testing.T) {
+	require.PanicsWithValue(t, "nil error", func() {
+		errors.Walk(nil, func([]int, error) bool { return true })
+	})
+
+	count := 0
+	errors.Walk(fmt.Errorf("test error"), func(path []int, err error) bool {
+		require.Empty(t, path)
+		require.EqualError(t, err, "test error")
+		count++
+		return true
+	})
+	require.Equal(t, 1, count)
+
+	err, errItem := newTestTree()
+	errors.Walk(err, func(path []int, err error) bool {
+		if fmt.Sprint(path) == "[0 1]" {
+			require.Equal(t, "item", errors.GetMetadata(err, "k"))
+			require.Equal(t, 1, errors.GetMetadata(err, "batch"))
+			require.Equal(t, "batch: sub-batch 1: item 2: ", errors.GetPrefix(err))
+			require.True(t, strings.HasPrefix(errors.GetFormattedCallers(err)[0], "errors_test.newTestTree"))
+		}
+		if fmt.Sprint(path) == "[0 0]" {
+			require.Equal(t, "sub-batch", errors.GetMetadata(err, "k"))
+			require.True(t, errors.Equals(err, errItem))
+		}
+		return true
+	})
+}
+
+func TestNest_Format(t *testing.T) {
+	err, _ := newTestTree()
+	s := fmt.Sprintf("%+v", err)
+
+	require.True(t, strings.HasPrefix(s, ""+
+		"batch: multiple errors: sub-batch 1: multiple errors: item error · item 2: other error · last error\n"+
+		"  prefix: batch\n"+
+		"  metadata:\n"+
+		"    batch: 1\n"+
+		"  stack:\n"), s)
+	require.Contains(t, s, "\n"+
+		"  errors:\n"+
+		"  [1/2] sub-batch 1: multiple errors: item error · item 2: other error\n"+
+		"      prefix: sub-batch 1\n"+
+		"      http status: 409\n"+
+		"      metadata:\n"+
+		"        k: sub-batch\n"+
+		"      stack:\n")
+	require.Contains(t, s, "\n"+
+		"      errors:\n"+
+		"      [1/2] item error\n"+
+		"          stack:\n"+
+		"            errors_test.newTestTree")
+	require.Contains(t, s, "\n  [2/2] last error\n")
+}
+
+func TestNest_JSON(t *testing.T) {
+	err, _ := newTestTree()
+
+	buf, jsonErr := errors.EncodeJSON(err)
+	require.NoError(t, jsonErr)
+
+	decoded, jsonErr := errors.DecodeJSON(buf)
+	require.NoError(t, jsonErr)
+	require.EqualError(t, decoded, err.Error())
+	require.Equal(t, "batch: ", errors.GetPrefix(decoded))
+
+	paths := make([]string, 0)
+	errors.Walk(decoded, func(path []int, err error) bool {
+		paths = append(paths, fmt.Sprintf("%v %v %v", path, err.Error(), errors.GetHTTPStatus(err)))
+		return true
+	})
+	require.Equal(t, []string{
+		"[] batch: multiple errors: sub-batch 1: multiple errors: item error · item 2: other error · last error 0",
+		"[0] batch: sub-batch 1: multiple errors: item error · item 2: other error 409",
+		"[0 0] batch: sub-batch 1: item error 409",
+		"[0 1] batch: sub-batch 1: item 2: other error 409",
+		"[1] batch: last error 0",
+	}, paths)
+}
+
+func TestNest_Problem(t *testing.T) {
+	err, _ := newTestTree()
+
+	p := errors.NewProblem(httptest.NewRequest(http.MethodGet, "/", nil), err)
+	require.Equal(t, http.StatusInternalServerError, p.Status)
+
+	inner := p.Extensions["errors"].([]*errors.Problem)
+	require.Len(t, inner, 2)
+	require.Equal(t, http.StatusConflict, inner[0].Status)
+	require.Len(t, inner[0].Extensions["errors"], 2)
+	require.Nil(t, inner[1].Extensions["errors"])
+
+	err = errors.Nest([]error{
+		errors.Errorf("first error", errors.Field("/name"), errors.PublicMessage("invalid name")),
+	}, errors.Field("/user"), errors.PublicMessage("invalid user"))
+	p = errors.NewProblem(nil, err)
+	require.Equal(t, "invalid user", p.Detail)
+	require.Equal(t, "/user", p.Extensions["pointer"])
+}
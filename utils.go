@@ -1,26 +1,12 @@
 package errors
 
-import (
-	"fmt"
-	"path/filepath"
-	"runtime"
-)
-
-// FormatCallers returns a human-readable version of callers.
+// FormatCallers returns a human-readable version of callers. See Frame.String for the format.
 func FormatCallers(callers []uintptr) []string {
-	frames := runtime.CallersFrames(callers)
-	formattedCallers := make([]string, 0, len(callers))
-
-	for {
-		frame, more := frames.Next()
+	frames := CallersFrames(callers)
+	formattedCallers := make([]string, 0, len(frames))
 
-		formattedCallers = append(
-			formattedCallers,
-			fmt.Sprintf("%v (%v:%v)", filepath.Base(frame.Function), frame.File, frame.Line))
-
-		if !more {
-			break
-		}
+	for _, frame := range frames {
+		formattedCallers = append(formattedCallers, frame.String())
 	}
 
 	return formattedCallers
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"fmt"
+)
+
+var fieldKey = NewKey[string]("errors.Field")
+
+// Field returns a Behavior that stores the path of the input field the error refers to, expressed as a JSON pointer
+// (RFC 6901), e.g. "/items/3/name".
+func Field(path string) Behavior {
+	return fieldKey.With(path)
+}
+
+// GetField extracts the path of the input field from the error metadata, if any.
+// It returns "" if no field was set.
+func GetField(err error) string {
+	return fieldKey.GetOrDefault(err, "")
+}
+
+// GetFieldErrors groups the inner errors of err by field (see Field), returning the public message of each error (see
+// PublicMessage) or its message if not set. Errors without a field are ignored. It returns nil if no field was set.
+func GetFieldErrors(err error) map[string][]string {
+	var fieldErrors map[string][]string
+
+	for _, err := range MaybeSplit(err) {
+		field := GetField(err)
+		if field == "" {
+			continue
+		}
+
+		if fieldErrors == nil {
+			fieldErrors = make(map[string][]string)
+		}
+		fieldErrors[field] = append(fieldErrors[field], GetPublicMessageOrDefault(err, Unwrap(err).Error()))
+	}
+
+	return fieldErrors
+}
+
+// ValidationErrors collects field-level validation errors into a compound error. The zero value is ready to use. It
+// is not safe for concurrent use.
+type ValidationErrors struct {
+	err error
+}
+
+// Add adds an error for the given field (see Field). The message is formatted as in Errorf, and is also stored as
+// public message. The error is given HTTPStatusUnprocessableEntity, which can be overridden by passing a different
+// HTTPStatus behavior.
+func (v *ValidationErrors) Add(field string, format string, behaviorOrArg ...interface{}) {
+	behaviors, args := splitBehaviorsAndArgs(behaviorOrArg)
+	message := fmt.Sprintf(format, args...)
+
+	behaviors = append([]Behavior{
+		formatKey.With(format),
+		HTTPStatusUnprocessableEntity,
+		PublicMessage(message),
+		Field(field),
+	}, behaviors...)
+	err := Wrap(fmt.Errorf(format, args...), append(behaviors, Skip(1))...)
+
+	if v.err == nil {
+		v.err = err
+	} else {
+		v.err = Append(v.err, err)
+	}
+}
+
+// AddIf is like Add, but only adds the error if cond is true.
+func (v *ValidationErrors) AddIf(cond bool, field string, format string, behaviorOrArg ...interface{}) {
+	if cond {
+		v.Add(field, format, append(behaviorOrArg, Skip(1))...)
+	}
+}
+
+// Len returns the number of errors collected so far.
+func (v *ValidationErrors) Len() int {
+	if v.err == nil {
+		return 0
+	}
+	return len(Split(v.err))
+}
+
+// Err returns the collected errors, or nil if no error was added. If more than one error was added, a compound error
+// is returned.
+func (v *ValidationErrors) Err() error {
+	return v.err
+}
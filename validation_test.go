@@ -0,0 +1,91 @@
+package errors_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ibrt/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleValidationErrors() {
+	name := ""
+	quantities := []int{1, 0}
+
+	v := &errors.ValidationErrors{}
+	v.AddIf(name == "", "/name", "must not be empty")
+	for i, quantity := range quantities {
+		v.AddIf(quantity < 1, fmt.Sprintf("/items/%v/quantity", i), "must be at least %v", 1)
+	}
+
+	err := v.Err()
+	fmt.Println(err.Error())
+	fmt.Println(errors.GetHTTPStatus(err))
+	fmt.Println(errors.GetFieldErrors(err))
+
+	// Output:
+	// multiple errors: must not be empty · must be at least 1
+	// 422
+	// map[/items/1/quantity:[must be at least 1] /name:[must not be empty]]
+}
+
+func TestValidationErrors(t *testing.T) {
+	v := &errors.ValidationErrors{}
+	require.Equal(t, 0, v.Len())
+	require.NoError(t, v.Err())
+
+	v.AddIf(false, "/name", "must not be empty")
+	require.NoError(t, v.Err())
+
+	v.AddIf(true, "/name", "must not be empty")
+	require.Equal(t, 1, v.Len())
+	require.EqualError(t, v.Err(), "must not be empty")
+	require.Equal(t, "/name", errors.GetField(v.Err()))
+	require.Equal(t, http.StatusUnprocessableEntity, errors.GetHTTPStatus(v.Err()))
+	require.True(t, strings.HasPrefix(errors.GetFormattedCallers(v.Err())[0], "errors_test.TestValidationErrors"))
+
+	v.Add("/name", "must be at most %v characters", 10, errors.PublicMessage("too long"))
+	v.Add("/email", "already taken", errors.HTTPStatusConflict)
+	require.Equal(t, 3, v.Len())
+	require.EqualError(t, v.Err(), "multiple errors: must not be empty · must be at most 10 characters · already taken")
+	require.Equal(t, http.StatusConflict, errors.GetHTTPStatus(v.Err()))
+	require.Equal(t, map[string][]string{
+		"/name":  {"must not be empty", "too long"},
+		"/email": {"already taken"},
+	}, errors.GetFieldErrors(v.Err()))
+
+	for _, err := range errors.Split(v.Err()) {
+		require.True(t, strings.HasPrefix(errors.GetFormattedCallers(err)[0], "errors_test.TestValidationErrors"))
+	}
+}
+
+func TestGetFieldErrors(t *testing.T) {
+	require.Nil(t, errors.GetFieldErrors(nil))
+	require.Nil(t, errors.GetFieldErrors(errors.Errorf("test error")))
+	require.Equal(t,
+		map[string][]string{"/name": {"test error"}},
+		errors.GetFieldErrors(errors.Append(errors.Errorf("test error"), errors.Errorf("test error", errors.Field("/name")))))
+}
+
+func TestValidationErrors_Problem(t *testing.T) {
+	v := &errors.ValidationErrors{}
+	v.Add("/name", "must not be empty")
+	v.Add("/items/0/quantity", "must be at least %v", 1)
+
+	w := httptest.NewRecorder()
+	errors.WriteProblem(w, nil, v.Err())
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "Unprocessable Entity",
+		"errors": [
+			{"type": "about:blank", "title": "Unprocessable Entity", "status": 422, "detail": "must not be empty", "pointer": "/name"},
+			{"type": "about:blank", "title": "Unprocessable Entity", "status": 422, "detail": "must be at least 1", "pointer": "/items/0/quantity"}
+		]
+	}`, w.Body.String())
+}